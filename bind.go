@@ -0,0 +1,52 @@
+package octo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type bindingKey struct {
+	typ  reflect.Type
+	name string
+}
+
+// Bind makes [Resolve] of interface Iface return the already registered Impl instance.
+// Resolution of bound interfaces does not scan registered types and
+// no second instance of Impl is created.
+//
+// Bindings are aliases, they are not returned by [ResolveAll] and [ResolveInjections].
+// Panics if Iface is not an interface, Impl does not implement it or Impl is not registered.
+func Bind[Iface, Impl any](container *Container) {
+	BindNamed[Iface, Impl](container, "")
+}
+
+// BindNamed makes [ResolveNamed] of interface Iface with the name
+// return the Impl instance registered with the same name.
+func BindNamed[Iface, Impl any](container *Container, name string) {
+	ifaceType := reflect.TypeFor[Iface]()
+	implType := reflect.TypeFor[Impl]()
+	if ifaceType.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("octo: cannot bind to non-interface type %s", ifaceType.String()))
+	}
+	if !implType.AssignableTo(ifaceType) {
+		panic(fmt.Sprintf("octo: type %s does not implement %s", implType.String(), ifaceType.String()))
+	}
+
+	container = containerOrDefault(container)
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	decl := resolveType(container, implType, name)
+	if decl == nil {
+		panic(fmt.Sprintf("octo: fail to bind %s, type %s is not registered", ifaceType.String(), implType.String()))
+	}
+
+	if container.bindings == nil {
+		container.bindings = make(map[bindingKey]Declaration)
+	}
+	container.bindings[bindingKey{typ: ifaceType, name: name}] = decl
+
+	container.resolveCacheMu.Lock()
+	delete(container.resolveCache, ifaceType)
+	container.resolveCacheMu.Unlock()
+}
@@ -0,0 +1,99 @@
+package octo_test
+
+import (
+	"testing"
+
+	"github.com/oesand/octo"
+)
+
+type GreeterService struct {
+	MyService
+}
+
+func TestBind_ResolvesBoundImpl(t *testing.T) {
+	c := octo.New()
+	octo.InjectValue(c, &MyService{name: "first"})
+
+	var created int
+	octo.Inject(c, func(c *octo.Container) *GreeterService {
+		created++
+		return &GreeterService{MyService{name: "bound"}}
+	})
+	octo.Bind[ServiceInterface, *GreeterService](c)
+
+	res := octo.Resolve[ServiceInterface](c)
+	if res.Name() != "bound" {
+		t.Fatalf("expected bound impl, got %q", res.Name())
+	}
+
+	if impl := octo.Resolve[*GreeterService](c); ServiceInterface(impl) != res {
+		t.Fatal("expected same instance for bound interface and impl")
+	}
+	if created != 1 {
+		t.Fatalf("expected impl created once, got %d", created)
+	}
+
+	if all := octo.ResolveAll[ServiceInterface](c); len(all) != 2 {
+		t.Fatalf("expected 2 services without duplicates, got %d", len(all))
+	}
+}
+
+func TestBindNamed(t *testing.T) {
+	c := octo.New()
+	octo.InjectNamedValue(c, "foo", &MyService{name: "foo"})
+	octo.InjectNamedValue(c, "bar", &MyService{name: "bar"})
+	octo.BindNamed[ServiceInterface, *MyService](c, "bar")
+
+	if res := octo.ResolveNamed[ServiceInterface](c, "bar"); res.Name() != "bar" {
+		t.Fatalf("expected bar, got %q", res.Name())
+	}
+	if res := octo.ResolveNamed[ServiceInterface](c, "foo"); res.Name() != "foo" {
+		t.Fatalf("expected foo, got %q", res.Name())
+	}
+}
+
+func TestBind_RemovedWithImpl(t *testing.T) {
+	c := octo.New()
+	octo.InjectValue(c, &MyService{})
+	octo.Bind[ServiceInterface, *MyService](c)
+
+	octo.CleanInjections(c, func(decl octo.Declaration) bool {
+		return octo.OfType[*MyService](decl)
+	})
+
+	if res := octo.TryResolve[ServiceInterface](c); res != nil {
+		t.Fatalf("expected binding removed, got %#v", res)
+	}
+}
+
+func TestBind_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		bind func(c *octo.Container)
+	}{
+		{
+			name: "NotInterface",
+			bind: func(c *octo.Container) { octo.Bind[*MyService, *MyService](c) },
+		},
+		{
+			name: "NotImplements",
+			bind: func(c *octo.Container) { octo.Bind[ServiceInterface, *OtherService](c) },
+		},
+		{
+			name: "NotRegistered",
+			bind: func(c *octo.Container) { octo.Bind[ServiceInterface, *MyService](c) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Fatal("expected panic")
+				}
+			}()
+
+			tt.bind(octo.New())
+		})
+	}
+}
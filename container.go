@@ -1,8 +1,10 @@
 package octo
 
 import (
+	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 
 	"github.com/oesand/octo/internal"
 )
@@ -18,40 +20,77 @@ func New() *Container {
 // Container stores service declarations and provides thread-safe access.
 type Container struct {
 	mu      sync.RWMutex
-	injects map[internal.ShadowType][]Declaration
+	injects map[reflect.Type][]Declaration
+	order   []reflect.Type
+
+	bindings map[bindingKey]Declaration
 
 	resolveCacheMu sync.RWMutex
-	resolveCache   map[internal.ShadowType]Declaration
+	resolveCache   map[reflect.Type]Declaration
+
+	modulesMu sync.Mutex
+	modules   internal.Set[*Module]
+
+	// target and installing are set for the view of the container
+	// passed to Module.Install, see moduleView.
+	target     *Container
+	installing *Module
+
+	parent       *Container
+	scopeMu      sync.Mutex
+	scopedValues map[Declaration]instantiableDeclaration
+	scopedOrder  []instantiableDeclaration
+	disposed     bool
 }
 
 func containerOrDefault(container *Container) *Container {
 	if container == nil {
 		return &DefaultContainer
 	}
+	if container.target != nil {
+		return container.target
+	}
 	return container
 }
 
-func injectLazy[T any](container *Container, name string, provider Provider[T]) {
-	var injection Declaration = &lazyInjection[T]{
-		container: container,
-		name:      name,
-		provider:  provider,
+// registrationTarget returns the container receiving registrations
+// and the module they are made by.
+func registrationTarget(container *Container) (*Container, *Module) {
+	if container != nil && container.target != nil {
+		return container.target, container.installing
 	}
+	return containerOrDefault(container), nil
+}
 
+func addInjection(container *Container, typ reflect.Type, injection Declaration) {
 	if container.injects == nil {
-		container.injects = make(map[internal.ShadowType][]Declaration)
+		container.injects = make(map[reflect.Type][]Declaration)
 	}
 
-	var key internal.Type[T]
-	container.injects[key] = append(container.injects[key], injection)
+	group, ok := container.injects[typ]
+	if !ok {
+		container.order = append(container.order, typ)
+	}
+	container.injects[typ] = append(group, injection)
+}
+
+func injectLazy[T any](container *Container, module *Module, name string, provider Provider[T]) {
+	addInjection(container, reflect.TypeFor[T](), &lazyInjection[T]{
+		container: container,
+		module:    module,
+		name:      name,
+		provider:  provider,
+	})
 }
 
 type lazyInjection[T any] struct {
 	container *Container
+	module    *Module
 	name      string
 
 	provider Provider[T]
 	doInit   sync.Once
+	done     atomic.Bool
 	value    T
 }
 
@@ -63,31 +102,35 @@ func (c *lazyInjection[T]) Name() string {
 	return c.name
 }
 
+func (c *lazyInjection[T]) Module() *Module {
+	return c.module
+}
+
 func (c *lazyInjection[T]) Value() any {
 	c.doInit.Do(func() {
 		c.value = c.provider(c.container)
+		c.done.Store(true)
 	})
 
 	return c.value
 }
 
-func injectValue[T any](container *Container, name string, value T) {
-	var injection Declaration = &valueInjection[T]{
-		name:  name,
-		value: value,
-	}
-
-	if container.injects == nil {
-		container.injects = make(map[internal.ShadowType][]Declaration)
-	}
+func (c *lazyInjection[T]) instantiated() bool {
+	return c.done.Load()
+}
 
-	var key internal.Type[T]
-	container.injects[key] = append(container.injects[key], injection)
+func injectValue[T any](container *Container, module *Module, name string, value T) {
+	addInjection(container, reflect.TypeFor[T](), &valueInjection[T]{
+		module: module,
+		name:   name,
+		value:  value,
+	})
 }
 
 type valueInjection[T any] struct {
-	name  string
-	value T
+	module *Module
+	name   string
+	value  T
 }
 
 func (c *valueInjection[T]) Type() reflect.Type {
@@ -98,6 +141,79 @@ func (c *valueInjection[T]) Name() string {
 	return c.name
 }
 
+func (c *valueInjection[T]) Module() *Module {
+	return c.module
+}
+
 func (c *valueInjection[T]) Value() any {
 	return c.value
 }
+
+func (c *valueInjection[T]) instantiated() bool {
+	return true
+}
+
+func injectReflect(container *Container, module *Module, typ reflect.Type, name string, provider func(*Container) (any, error)) {
+	addInjection(container, typ, &reflectInjection{
+		container: container,
+		module:    module,
+		typ:       typ,
+		name:      name,
+		provider:  provider,
+	})
+}
+
+type reflectInjection struct {
+	container *Container
+	module    *Module
+	typ       reflect.Type
+	name      string
+
+	provider func(*Container) (any, error)
+	doInit   sync.Once
+	done     atomic.Bool
+	value    any
+	err      error
+}
+
+func (c *reflectInjection) Type() reflect.Type {
+	return c.typ
+}
+
+func (c *reflectInjection) Name() string {
+	return c.name
+}
+
+func (c *reflectInjection) Module() *Module {
+	return c.module
+}
+
+func (c *reflectInjection) resolve() (any, error) {
+	c.doInit.Do(func() {
+		value, err := c.provider(c.container)
+		if err == nil && value != nil && !reflect.TypeOf(value).AssignableTo(c.typ) {
+			err = fmt.Errorf("octo: provider returned %T which is not assignable to %s", value, c.typ.String())
+		}
+
+		if err != nil {
+			c.err = err
+		} else {
+			c.value = value
+		}
+		c.done.Store(true)
+	})
+
+	return c.value, c.err
+}
+
+func (c *reflectInjection) Value() any {
+	value, err := c.resolve()
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+func (c *reflectInjection) instantiated() bool {
+	return c.done.Load()
+}
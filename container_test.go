@@ -1,8 +1,11 @@
 package octo_test
 
 import (
+	"errors"
+	"reflect"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/oesand/octo"
 )
@@ -479,3 +482,104 @@ func TestPanicWhenInjectValueContainer(t *testing.T) {
 	c := octo.New()
 	octo.InjectValue(c, c)
 }
+
+func TestInjectTypeAndResolveType(t *testing.T) {
+	c := octo.New()
+	typ := reflect.TypeFor[*MyService]()
+	octo.InjectType(c, typ, "foo", func(c *octo.Container) (any, error) {
+		return &MyService{name: "foo"}, nil
+	})
+
+	res, err := octo.ResolveType(c, typ, "foo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if srv, ok := res.(*MyService); !ok || srv.name != "foo" {
+		t.Fatalf("expected MyService foo, got %#v", res)
+	}
+
+	if srv := octo.ResolveNamed[*MyService](c, "foo"); srv != res {
+		t.Fatalf("expected generic resolve return same instance, got %#v", srv)
+	}
+
+	iface, err := octo.ResolveType(c, reflect.TypeFor[ServiceInterface](), "")
+	if err != nil || iface != res {
+		t.Fatalf("expected resolve by interface return same instance, got %#v, %v", iface, err)
+	}
+}
+
+func TestResolveTypeOfGenericInjection(t *testing.T) {
+	c := octo.New()
+	octo.InjectValue(c, &MyService{name: "generic"})
+
+	res, err := octo.ResolveType(c, reflect.TypeFor[*MyService](), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.(*MyService).name != "generic" {
+		t.Fatalf("expected generic value, got %#v", res)
+	}
+}
+
+func TestResolveTypeErrors(t *testing.T) {
+	c := octo.New()
+	providerErr := errors.New("provider failed")
+	octo.InjectType(c, reflect.TypeFor[*MyService](), "", func(c *octo.Container) (any, error) {
+		return nil, providerErr
+	})
+	octo.InjectType(c, reflect.TypeFor[*OtherService](), "", func(c *octo.Container) (any, error) {
+		return &MyService{}, nil
+	})
+
+	if _, err := octo.ResolveType(c, reflect.TypeFor[*MyService](), ""); !errors.Is(err, providerErr) {
+		t.Fatalf("expected provider error, got %v", err)
+	}
+
+	if _, err := octo.ResolveType(c, reflect.TypeFor[*OtherService](), ""); err == nil {
+		t.Fatal("expected error for not assignable value")
+	}
+
+	_, err := octo.ResolveType(c, reflect.TypeFor[ServiceInterface](), "missing")
+	if err == nil || err.Error() != "octo: fail to resolve type octo_test.ServiceInterface" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestInjectInsideProvider(t *testing.T) {
+	c := octo.New()
+	octo.Inject(c, func(c *octo.Container) *MyService {
+		octo.InjectValue(c, &OtherService{})
+		return &MyService{name: "plugin"}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		octo.Resolve[*MyService](c)
+		if octo.TryResolve[*OtherService](c) == nil {
+			t.Error("expected OtherService registered by provider")
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("deadlock on inject inside provider")
+	}
+}
+
+func TestResolveInsideCleanSelector(t *testing.T) {
+	c := octo.New()
+	octo.InjectValue(c, &MyService{name: "foo"})
+	octo.InjectValue(c, &OtherService{})
+
+	octo.CleanInjections(c, func(decl octo.Declaration) bool {
+		octo.TryInjectNamedValue(c, "late", &MyService{name: "late"})
+		return octo.OfType[*MyService](decl) && decl.Value() == octo.Resolve[*MyService](c)
+	})
+
+	res := octo.ResolveAll[*MyService](c)
+	if len(res) != 1 || res[0].name != "late" {
+		t.Fatalf("expected only late MyService remain, got %#v", res)
+	}
+}
@@ -0,0 +1,186 @@
+package octo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultHealthTimeout limits the duration of a single health check.
+const DefaultHealthTimeout = 5 * time.Second
+
+// HealthChecker is implemented by services that can report their health,
+// e.g. database pools, caches or background workers.
+type HealthChecker interface {
+	// HealthCheck returns a non-nil error when the service is unhealthy.
+	HealthCheck(ctx context.Context) error
+}
+
+// HealthStatus describes the state of a service or a whole container.
+type HealthStatus string
+
+const (
+	HealthUp   HealthStatus = "up"
+	HealthDown HealthStatus = "down"
+)
+
+// HealthCheck is the result of a single [HealthChecker] run.
+type HealthCheck struct {
+	Name     string        `json:"name,omitempty"`
+	Type     string        `json:"type"`
+	Status   HealthStatus  `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// HealthReport aggregates results of all health checks in a container.
+// Status is [HealthDown] if at least one check failed.
+type HealthReport struct {
+	Status HealthStatus  `json:"status"`
+	Checks []HealthCheck `json:"checks,omitempty"`
+}
+
+// HealthOption represents a function that modifies health check configuration.
+type HealthOption func(*healthOptions)
+
+type healthOptions struct {
+	timeout time.Duration
+}
+
+// WithHealthTimeout sets the timeout of every single health check.
+func WithHealthTimeout(timeout time.Duration) HealthOption {
+	return func(o *healthOptions) {
+		o.timeout = timeout
+	}
+}
+
+type instantiable interface {
+	instantiated() bool
+}
+
+// Health runs checks of all instantiated services implementing [HealthChecker]
+// concurrently and returns the aggregated report.
+//
+// Lazy injections which were not resolved yet are skipped, health checks never construct services.
+// Injections whose provider failed are reported as failed checks.
+func Health(ctx context.Context, container *Container, options ...HealthOption) HealthReport {
+	opts := healthOptions{timeout: DefaultHealthTimeout}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	var decls []Declaration
+	var checkers []HealthChecker
+	var failed []HealthCheck
+	for decl := range ResolveInjections(container) {
+		if inst, ok := decl.(instantiable); ok && !inst.instantiated() {
+			continue
+		}
+
+		if inject, ok := decl.(*reflectInjection); ok {
+			if _, err := inject.resolve(); err != nil {
+				failed = append(failed, HealthCheck{
+					Name:   decl.Name(),
+					Type:   decl.Type().String(),
+					Status: HealthDown,
+					Error:  err.Error(),
+				})
+				continue
+			}
+		}
+		if checker, ok := decl.Value().(HealthChecker); ok {
+			decls = append(decls, decl)
+			checkers = append(checkers, checker)
+		}
+	}
+
+	report := HealthReport{
+		Status: HealthUp,
+		Checks: make([]HealthCheck, len(checkers), len(checkers)+len(failed)),
+	}
+
+	done := make(chan struct{}, len(checkers))
+	for i, checker := range checkers {
+		go func() {
+			report.Checks[i] = runHealthCheck(ctx, decls[i], checker, opts.timeout)
+			done <- struct{}{}
+		}()
+	}
+
+	for range checkers {
+		<-done
+	}
+	report.Checks = append(report.Checks, failed...)
+
+	for _, check := range report.Checks {
+		if check.Status != HealthUp {
+			report.Status = HealthDown
+			break
+		}
+	}
+
+	return report
+}
+
+func runHealthCheck(ctx context.Context, decl Declaration, checker HealthChecker, timeout time.Duration) HealthCheck {
+	check := HealthCheck{
+		Name:   decl.Name(),
+		Type:   decl.Type().String(),
+		Status: HealthUp,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	started := time.Now()
+	result := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				result <- errors.New("octo: health check panicked")
+			}
+		}()
+		result <- checker.HealthCheck(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		err = context.Cause(ctx)
+	}
+
+	check.Duration = time.Since(started)
+	if err != nil {
+		check.Status = HealthDown
+		check.Error = err.Error()
+	}
+	return check
+}
+
+// HealthHandler returns a [http.Handler] exposing container health as JSON.
+//
+// Requests with path ending on "/live" are answered with [HealthUp] without running checks,
+// all other requests are answered with the [Health] report.
+// Responds with status 503 when the container is [HealthDown].
+//
+// Example:
+//
+//	http.Handle("/health/", octo.HealthHandler(container))
+func HealthHandler(container *Container, options ...HealthOption) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := HealthReport{Status: HealthUp}
+		if !strings.HasSuffix(r.URL.Path, "/live") {
+			report = Health(r.Context(), container, options...)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if report.Status != HealthUp {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
@@ -0,0 +1,136 @@
+package octo_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oesand/octo"
+)
+
+type HealthyService struct {
+	err   error
+	delay time.Duration
+}
+
+func (s *HealthyService) HealthCheck(ctx context.Context) error {
+	if s.delay > 0 {
+		select {
+		case <-time.After(s.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return s.err
+}
+
+func TestHealth_AllUp(t *testing.T) {
+	c := octo.New()
+	octo.InjectValue(c, &HealthyService{})
+	octo.InjectNamedValue(c, "other", &HealthyService{})
+	octo.InjectValue(c, &MyService{})
+
+	report := octo.Health(context.Background(), c)
+	if report.Status != octo.HealthUp {
+		t.Fatalf("expected up, got %s", report.Status)
+	}
+	if len(report.Checks) != 2 {
+		t.Fatalf("expected 2 checks, got %d", len(report.Checks))
+	}
+}
+
+func TestHealth_FailedAndTimedOut(t *testing.T) {
+	c := octo.New()
+	octo.InjectValue(c, &HealthyService{err: errors.New("connection refused")})
+	octo.InjectNamedValue(c, "slow", &HealthyService{delay: time.Second})
+
+	report := octo.Health(context.Background(), c, octo.WithHealthTimeout(10*time.Millisecond))
+	if report.Status != octo.HealthDown {
+		t.Fatalf("expected down, got %s", report.Status)
+	}
+
+	for _, check := range report.Checks {
+		if check.Status != octo.HealthDown {
+			t.Fatalf("expected check %q down", check.Name)
+		}
+		if check.Name == "slow" && check.Error != context.DeadlineExceeded.Error() {
+			t.Fatalf("expected deadline error, got %q", check.Error)
+		}
+	}
+}
+
+func TestHealth_SkipsNotInstantiated(t *testing.T) {
+	c := octo.New()
+	var created bool
+	octo.Inject(c, func(c *octo.Container) *HealthyService {
+		created = true
+		return &HealthyService{err: errors.New("down")}
+	})
+
+	report := octo.Health(context.Background(), c)
+	if created {
+		t.Fatal("expected lazy service not created")
+	}
+	if report.Status != octo.HealthUp || len(report.Checks) != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	octo.Resolve[*HealthyService](c)
+	if report = octo.Health(context.Background(), c); report.Status != octo.HealthDown {
+		t.Fatalf("expected down after resolve, got %s", report.Status)
+	}
+}
+
+func TestHealth_FailedProvider(t *testing.T) {
+	c := octo.New()
+	octo.InjectType(c, reflect.TypeFor[*HealthyService](), "", func(c *octo.Container) (any, error) {
+		return nil, errors.New("provider failed")
+	})
+	if _, err := octo.ResolveType(c, reflect.TypeFor[*HealthyService](), ""); err == nil {
+		t.Fatal("expected provider error")
+	}
+
+	report := octo.Health(context.Background(), c)
+	if report.Status != octo.HealthDown || len(report.Checks) != 1 || report.Checks[0].Error != "provider failed" {
+		t.Fatalf("expected failed provider reported, got %+v", report)
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	c := octo.New()
+	octo.InjectValue(c, &HealthyService{err: errors.New("down")})
+	handler := octo.HealthHandler(c)
+
+	tests := []struct {
+		path   string
+		code   int
+		status octo.HealthStatus
+	}{
+		{path: "/health/live", code: http.StatusOK, status: octo.HealthUp},
+		{path: "/health/ready", code: http.StatusServiceUnavailable, status: octo.HealthDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.code {
+				t.Fatalf("expected code %d, got %d", tt.code, rec.Code)
+			}
+
+			var report octo.HealthReport
+			if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if report.Status != tt.status {
+				t.Fatalf("expected %s, got %s", tt.status, report.Status)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package octo
 
+import "reflect"
+
 func ensureCanInjectType[T any]() {
 	var t T
 	switch any(t).(type) {
@@ -17,7 +19,7 @@ func TryInjectValue[T any](container *Container, value T) bool {
 func TryInjectNamedValue[T any](container *Container, name string, value T) bool {
 	ensureCanInjectType[T]()
 
-	container = containerOrDefault(container)
+	container, module := registrationTarget(container)
 	container.mu.Lock()
 	defer container.mu.Unlock()
 
@@ -25,7 +27,7 @@ func TryInjectNamedValue[T any](container *Container, name string, value T) bool
 		return false
 	}
 
-	injectValue(container, name, value)
+	injectValue(container, module, name, value)
 	return true
 }
 
@@ -38,11 +40,11 @@ func InjectValue[T any](container *Container, value T) {
 func InjectNamedValue[T any](container *Container, name string, value T) {
 	ensureCanInjectType[T]()
 
-	container = containerOrDefault(container)
+	container, module := registrationTarget(container)
 	container.mu.Lock()
 	defer container.mu.Unlock()
 
-	injectValue(container, name, value)
+	injectValue(container, module, name, value)
 }
 
 // TryInject registers a provider function to lazily resolve a type if not registered.
@@ -54,7 +56,7 @@ func TryInject[T any](container *Container, provider Provider[T]) bool {
 func TryInjectNamed[T any](container *Container, name string, provider Provider[T]) bool {
 	ensureCanInjectType[T]()
 
-	container = containerOrDefault(container)
+	container, module := registrationTarget(container)
 	container.mu.Lock()
 	defer container.mu.Unlock()
 
@@ -62,7 +64,7 @@ func TryInjectNamed[T any](container *Container, name string, provider Provider[
 		return false
 	}
 
-	injectLazy(container, name, provider)
+	injectLazy(container, module, name, provider)
 	return true
 }
 
@@ -75,9 +77,28 @@ func Inject[T any](container *Container, provider Provider[T]) {
 func InjectNamed[T any](container *Container, name string, provider Provider[T]) {
 	ensureCanInjectType[T]()
 
-	container = containerOrDefault(container)
+	container, module := registrationTarget(container)
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	injectLazy(container, module, name, provider)
+}
+
+// InjectType registers a named provider function to lazily resolve a value of type typ.
+// Provider must return a value assignable to typ, otherwise resolution fails.
+//
+// Not recommended, use if generics are not applicable in your case
+func InjectType(container *Container, typ reflect.Type, name string, provider func(*Container) (any, error)) {
+	if typ == nil {
+		panic("octo: type must not be nil")
+	}
+	if typ == containerType {
+		panic("cannot inject Container")
+	}
+
+	container, module := registrationTarget(container)
 	container.mu.Lock()
 	defer container.mu.Unlock()
 
-	injectLazy(container, name, provider)
+	injectReflect(container, module, typ, name, provider)
 }
@@ -0,0 +1,212 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+const (
+	DefaultAsyncWorkers  = 4
+	DefaultAsyncCapacity = 1024
+)
+
+var (
+	// ErrQueueFull is returned by [PublishAsync] when the queue is full and [ErrorOnFull] policy is used.
+	ErrQueueFull = errors.New("mediator: async queue is full")
+
+	// ErrManagerClosed is returned by [PublishAsync] after [Manager.Shutdown].
+	ErrManagerClosed = errors.New("mediator: manager is shut down")
+)
+
+// BackpressurePolicy defines behavior of [PublishAsync] when the queue is full.
+type BackpressurePolicy int
+
+const (
+	// BlockOnFull blocks the caller until the queue has space or the context is canceled.
+	BlockOnFull BackpressurePolicy = iota
+
+	// DropOldest drops the oldest queued event to make space for the new one.
+	DropOldest
+
+	// ErrorOnFull returns [ErrQueueFull] immediately.
+	ErrorOnFull
+)
+
+// WithAsyncWorkers sets the number of workers handling events published by [PublishAsync].
+func WithAsyncWorkers(workers int) Option {
+	return func(m *Manager) {
+		m.asyncWorkers = workers
+	}
+}
+
+// WithAsyncCapacity sets the capacity of the [PublishAsync] queue.
+func WithAsyncCapacity(capacity int) Option {
+	return func(m *Manager) {
+		m.asyncCapacity = capacity
+	}
+}
+
+// WithBackpressure sets the policy used by [PublishAsync] when the queue is full.
+func WithBackpressure(policy BackpressurePolicy) Option {
+	return func(m *Manager) {
+		m.asyncPolicy = policy
+	}
+}
+
+// WithAsyncErrorHandler sets the function receiving errors of events published by [PublishAsync].
+// Events dropped by [DropOldest] policy are reported with [ErrQueueFull].
+func WithAsyncErrorHandler(handler func(ctx context.Context, event any, err error)) Option {
+	return func(m *Manager) {
+		m.asyncErrorHandler = handler
+	}
+}
+
+type asyncEvent struct {
+	ctx   context.Context
+	event any
+}
+
+type asyncQueue struct {
+	manager *Manager
+	policy  BackpressurePolicy
+	events  chan asyncEvent
+	workers sync.WaitGroup
+
+	mu        sync.RWMutex
+	closeOnce sync.Once
+	closing   chan struct{}
+	closed    bool
+}
+
+// PublishAsync enqueues the event to be published by background workers and returns immediately.
+// The queue is started on the first call and configured by manager options:
+// [WithAsyncWorkers], [WithAsyncCapacity], [WithBackpressure] and [WithAsyncErrorHandler].
+//
+// Handlers receive the context values but not its cancellation.
+// Call [Manager.Shutdown] to wait until queued events are handled.
+func PublishAsync(manager *Manager, ctx context.Context, event any) error {
+	manager.ensureInit()
+	queue := manager.asyncQueue()
+
+	queue.mu.RLock()
+	defer queue.mu.RUnlock()
+	if queue.closed {
+		return ErrManagerClosed
+	}
+
+	item := asyncEvent{ctx: context.WithoutCancel(ctx), event: event}
+	switch queue.policy {
+	case ErrorOnFull:
+		select {
+		case queue.events <- item:
+			return nil
+		default:
+			return ErrQueueFull
+		}
+	case DropOldest:
+		for {
+			select {
+			case queue.events <- item:
+				return nil
+			default:
+			}
+
+			select {
+			case dropped := <-queue.events:
+				manager.reportAsyncError(dropped, ErrQueueFull)
+			default:
+			}
+		}
+	default:
+		select {
+		case queue.events <- item:
+			return nil
+		case <-queue.closing:
+			return ErrManagerClosed
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+
+func (m *Manager) asyncQueue() *asyncQueue {
+	m.asyncOnce.Do(func() {
+		workers := m.asyncWorkers
+		if workers <= 0 {
+			workers = DefaultAsyncWorkers
+		}
+		capacity := m.asyncCapacity
+		if capacity <= 0 {
+			capacity = DefaultAsyncCapacity
+		}
+
+		queue := &asyncQueue{
+			manager: m,
+			policy:  m.asyncPolicy,
+			events:  make(chan asyncEvent, capacity),
+			closing: make(chan struct{}),
+		}
+		for range workers {
+			queue.workers.Go(queue.work)
+		}
+		m.async = queue
+	})
+	return m.async
+}
+
+func (q *asyncQueue) work() {
+	for item := range q.events {
+		if err := Publish(q.manager, item.ctx, item.event); err != nil {
+			q.manager.reportAsyncError(item, err)
+		}
+	}
+}
+
+func (m *Manager) reportAsyncError(item asyncEvent, err error) {
+	if m.asyncErrorHandler != nil {
+		m.asyncErrorHandler(item.ctx, item.event, err)
+	}
+}
+
+// Shutdown stops accepting events by [PublishAsync] and waits until
+// already queued events are handled or the context is canceled.
+//
+// Scheduler of [PublishAt] is stopped as well, events not published yet
+// are kept in the schedule store.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.ensureInit()
+	if err := m.shutdownScheduler(ctx); err != nil {
+		return err
+	}
+	// Queue is not started just to be closed, later calls of PublishAsync find it closed
+	m.asyncOnce.Do(func() {
+		m.async = &asyncQueue{closing: make(chan struct{}), closed: true}
+	})
+	queue := m.async
+
+	// Unblock producers waiting for space before taking the lock they hold
+	queue.closeOnce.Do(func() {
+		close(queue.closing)
+	})
+
+	queue.mu.Lock()
+	if !queue.closed {
+		queue.closed = true
+		close(queue.events)
+	}
+	queue.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		queue.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
@@ -0,0 +1,163 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+)
+
+type GateHandler struct {
+	Started chan struct{}
+	Release chan struct{}
+	Handled atomic.Int32
+}
+
+func (h *GateHandler) Notification(ctx context.Context, e EventX) error {
+	select {
+	case h.Started <- struct{}{}:
+	default:
+	}
+	<-h.Release
+	h.Handled.Add(1)
+	return nil
+}
+
+func TestPublishAsync_DrainOnShutdown(t *testing.T) {
+	container := octo.New()
+	h := &EventHandlerX{}
+	octo.InjectValue(container, h)
+	manager := mediator.Inject(container)
+
+	if err := mediator.PublishAsync(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := manager.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !h.Called.Load() {
+		t.Fatal("expected handler called before shutdown returned")
+	}
+
+	if err := mediator.PublishAsync(manager, context.Background(), EventX{}); !errors.Is(err, mediator.ErrManagerClosed) {
+		t.Fatalf("expected closed error, got %v", err)
+	}
+}
+
+func TestPublishAsync_ShutdownBeforeUse(t *testing.T) {
+	manager := mediator.Inject(octo.New())
+	if err := manager.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mediator.PublishAsync(manager, context.Background(), EventX{}); !errors.Is(err, mediator.ErrManagerClosed) {
+		t.Fatalf("expected closed error, got %v", err)
+	}
+}
+
+func TestPublishAsync_ErrorOnFull(t *testing.T) {
+	container := octo.New()
+	h := &GateHandler{Started: make(chan struct{}, 1), Release: make(chan struct{})}
+	octo.InjectValue(container, h)
+	manager := mediator.Inject(container,
+		mediator.WithAsyncWorkers(1), mediator.WithAsyncCapacity(1), mediator.WithBackpressure(mediator.ErrorOnFull))
+	ctx := context.Background()
+
+	if err := mediator.PublishAsync(manager, ctx, EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-h.Started
+	if err := mediator.PublishAsync(manager, ctx, EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mediator.PublishAsync(manager, ctx, EventX{}); !errors.Is(err, mediator.ErrQueueFull) {
+		t.Fatalf("expected queue full error, got %v", err)
+	}
+
+	close(h.Release)
+	if err := manager.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := h.Handled.Load(); n != 2 {
+		t.Fatalf("expected 2 handled events, got %d", n)
+	}
+}
+
+func TestPublishAsync_DropOldest(t *testing.T) {
+	var dropped []string
+	var mu sync.Mutex
+	container := octo.New()
+	h := &GateHandler{Started: make(chan struct{}, 1), Release: make(chan struct{})}
+	octo.InjectValue(container, h)
+	manager := mediator.Inject(container,
+		mediator.WithAsyncWorkers(1),
+		mediator.WithAsyncCapacity(1),
+		mediator.WithBackpressure(mediator.DropOldest),
+		mediator.WithAsyncErrorHandler(func(ctx context.Context, event any, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if errors.Is(err, mediator.ErrQueueFull) {
+				dropped = append(dropped, event.(EventX).Name)
+			}
+		}),
+	)
+	ctx := context.Background()
+
+	if err := mediator.PublishAsync(manager, ctx, EventX{Name: "first"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-h.Started
+	for _, name := range []string{"second", "third"} {
+		if err := mediator.PublishAsync(manager, ctx, EventX{Name: name}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	close(h.Release)
+	if err := manager.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(dropped) != 1 || dropped[0] != "second" {
+		t.Fatalf("expected second dropped, got %v", dropped)
+	}
+	if n := h.Handled.Load(); n != 2 {
+		t.Fatalf("expected 2 handled events, got %d", n)
+	}
+}
+
+func TestPublishAsync_BlockOnFull(t *testing.T) {
+	container := octo.New()
+	h := &GateHandler{Started: make(chan struct{}, 1), Release: make(chan struct{})}
+	octo.InjectValue(container, h)
+	manager := mediator.Inject(container, mediator.WithAsyncWorkers(1), mediator.WithAsyncCapacity(1))
+
+	if err := mediator.PublishAsync(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-h.Started
+	if err := mediator.PublishAsync(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := mediator.PublishAsync(manager, ctx, EventX{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelShutdown()
+	if err := manager.Shutdown(shutdownCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected shutdown deadline error, got %v", err)
+	}
+
+	close(h.Release)
+	if err := manager.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
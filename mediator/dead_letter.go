@@ -0,0 +1,85 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrHandlerNotFound is returned by [Replay] when the handler of the dead letter is no longer registered.
+var ErrHandlerNotFound = errors.New("mediator: handler not found")
+
+// DeadLetter is an event whose handler failed all retry attempts.
+type DeadLetter struct {
+	Event    any
+	Handler  HandlerInfo
+	Err      error
+	Attempts int
+	At       time.Time
+}
+
+// DeadLetterSink receives dead letters produced by [Retry] behavior.
+type DeadLetterSink interface {
+	Put(ctx context.Context, letter DeadLetter) error
+}
+
+// Replay delivers the dead letter event again to the handler which failed it,
+// the handler is matched by [HandlerInfo.ID].
+// Event behaviors are applied, so a failed replay is retried and dead-lettered again.
+func Replay(manager *Manager, ctx context.Context, letter DeadLetter) error {
+	for _, handler := range manager.dispatch().handlersFor(reflect.TypeOf(letter.Event)) {
+		if handler.info.ID == letter.Handler.ID {
+			if err := handler.handle(ctx, letter.Event); err != nil {
+				return &HandlerError{Handler: handler.info, Err: err}
+			}
+			return nil
+		}
+	}
+	return ErrHandlerNotFound
+}
+
+var _ DeadLetterSink = &MemoryDeadLetters{}
+
+// MemoryDeadLetters is an in-memory implementation of DeadLetterSink.
+type MemoryDeadLetters struct {
+	mu      sync.Mutex
+	letters []DeadLetter
+}
+
+func (s *MemoryDeadLetters) Put(_ context.Context, letter DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.letters = append(s.letters, letter)
+	return nil
+}
+
+// Letters returns stored dead letters in the order they were received.
+func (s *MemoryDeadLetters) Letters() []DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.letters)
+}
+
+// Replay removes all stored dead letters and replays them with [Replay].
+// Letters which failed again are put back by the retry behavior,
+// letters which could not be replayed are kept and their errors are returned joined.
+func (s *MemoryDeadLetters) Replay(manager *Manager, ctx context.Context) error {
+	s.mu.Lock()
+	letters := s.letters
+	s.letters = nil
+	s.mu.Unlock()
+
+	var errs []error
+	for _, letter := range letters {
+		if err := Replay(manager, ctx, letter); err != nil {
+			errs = append(errs, err)
+			_ = s.Put(ctx, letter)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,78 @@
+package mediator
+
+import (
+	"context"
+	"time"
+
+	"github.com/oesand/octo/mc"
+)
+
+// IdentifiedEvent is implemented by events carrying a unique id,
+// redelivered copies of the event must return the same id.
+type IdentifiedEvent interface {
+	EventID() string
+}
+
+// DedupStore records ids of events processed by handlers.
+type DedupStore interface {
+	// Seen reports whether the key was marked as processed.
+	Seen(ctx context.Context, key string) (bool, error)
+
+	// MarkSeen marks the key as processed.
+	MarkSeen(ctx context.Context, key string) error
+}
+
+// Deduplicate returns an [EventBehavior] which skips handlers for events
+// already processed by them, handlers are identified by [HandlerInfo.ID].
+// Only events implementing [IdentifiedEvent] are deduplicated,
+// the event is marked as processed by the handler only if it succeeded.
+//
+// Concurrent deliveries of the same event are not serialized,
+// so the behavior protects from redelivery but not from simultaneous duplicates.
+func Deduplicate(store DedupStore) EventBehavior {
+	return EventBehaviorFunc(func(ctx context.Context, handler HandlerInfo, event any, next func(ctx context.Context) error) error {
+		identified, ok := event.(IdentifiedEvent)
+		if !ok {
+			return next(ctx)
+		}
+
+		key := handler.ID + "/" + identified.EventID()
+		seen, err := store.Seen(ctx, key)
+		if err != nil {
+			return err
+		}
+		if seen {
+			return nil
+		}
+
+		if err = next(ctx); err != nil {
+			return err
+		}
+		return store.MarkSeen(ctx, key)
+	})
+}
+
+const memCacheDedupPrefix = "mediator/dedup/"
+
+type memCacheDedupStore struct {
+	cache *mc.MemCache
+	ttl   time.Duration
+}
+
+// MemCacheDedupStore returns a DedupStore keeping processed ids in the cache for the ttl,
+// which must be longer than the redelivery window of the event source.
+func MemCacheDedupStore(cache *mc.MemCache, ttl time.Duration) DedupStore {
+	return &memCacheDedupStore{cache: cache, ttl: ttl}
+}
+
+func (s *memCacheDedupStore) Seen(_ context.Context, key string) (bool, error) {
+	found, _, _ := mc.TryGet[struct{}](s.cache, memCacheDedupPrefix+key)
+	return found, nil
+}
+
+func (s *memCacheDedupStore) MarkSeen(_ context.Context, key string) error {
+	_, err := mc.GetOrCreate(s.cache, memCacheDedupPrefix+key, s.ttl, func() (struct{}, error) {
+		return struct{}{}, nil
+	})
+	return err
+}
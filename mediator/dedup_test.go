@@ -0,0 +1,130 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mc"
+	"github.com/oesand/octo/mediator"
+)
+
+type PaymentReceived struct {
+	ID string
+}
+
+func (e PaymentReceived) EventID() string { return e.ID }
+
+type PaymentHandler struct {
+	Calls atomic.Int32
+	Fail  atomic.Bool
+}
+
+func (h *PaymentHandler) Notification(ctx context.Context, e PaymentReceived) error {
+	h.Calls.Add(1)
+	if h.Fail.Load() {
+		return errHandler
+	}
+	return nil
+}
+
+func TestDeduplicate_SkipsRedelivery(t *testing.T) {
+	container := octo.New()
+	h := &PaymentHandler{}
+	octo.InjectValue(container, h)
+	octo.InjectValue(container, mediator.Deduplicate(mediator.MemCacheDedupStore(mc.New(), time.Minute)))
+	manager := mediator.Inject(container)
+
+	for _, id := range []string{"1", "1", "2"} {
+		if err := mediator.Publish(manager, context.Background(), PaymentReceived{ID: id}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := h.Calls.Load(); n != 2 {
+		t.Fatalf("expected 2 calls, got %d", n)
+	}
+}
+
+func TestDeduplicate_FailedNotMarked(t *testing.T) {
+	container := octo.New()
+	h := &PaymentHandler{}
+	octo.InjectValue(container, h)
+	octo.InjectValue(container, mediator.Deduplicate(mediator.MemCacheDedupStore(mc.New(), time.Minute)))
+	manager := mediator.Inject(container)
+	ctx := context.Background()
+
+	h.Fail.Store(true)
+	if err := mediator.Publish(manager, ctx, PaymentReceived{ID: "1"}); !errors.Is(err, errHandler) {
+		t.Fatalf("expected handler error, got %v", err)
+	}
+	h.Fail.Store(false)
+	if err := mediator.Publish(manager, ctx, PaymentReceived{ID: "1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := h.Calls.Load(); n != 2 {
+		t.Fatalf("expected failed event redelivered, got %d calls", n)
+	}
+}
+
+func TestDeduplicate_Expired(t *testing.T) {
+	container := octo.New()
+	h := &PaymentHandler{}
+	octo.InjectValue(container, h)
+	octo.InjectValue(container, mediator.Deduplicate(mediator.MemCacheDedupStore(mc.New(), 10*time.Millisecond)))
+	manager := mediator.Inject(container)
+	ctx := context.Background()
+
+	if err := mediator.Publish(manager, ctx, PaymentReceived{ID: "1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if err := mediator.Publish(manager, ctx, PaymentReceived{ID: "1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := h.Calls.Load(); n != 2 {
+		t.Fatalf("expected event handled again after ttl, got %d calls", n)
+	}
+}
+
+func TestDeduplicate_WithoutID(t *testing.T) {
+	container := octo.New()
+	h := &EventHandlerX{}
+	octo.InjectValue(container, h)
+	octo.InjectValue(container, mediator.Deduplicate(mediator.MemCacheDedupStore(mc.New(), time.Minute)))
+	manager := mediator.Inject(container)
+
+	if err := mediator.Publish(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !h.Called.Load() {
+		t.Fatal("expected event without id handled")
+	}
+}
+
+func TestDeduplicate_SameTypeHandlers(t *testing.T) {
+	container := octo.New()
+	var first, second atomic.Int32
+	mediator.HandleEventFunc(container, func(ctx context.Context, e PaymentReceived) error {
+		first.Add(1)
+		return nil
+	})
+	mediator.HandleEventFunc(container, func(ctx context.Context, e PaymentReceived) error {
+		second.Add(1)
+		return nil
+	})
+	octo.InjectValue(container, mediator.Deduplicate(mediator.MemCacheDedupStore(mc.New(), time.Minute)))
+	manager := mediator.Inject(container, mediator.WithPublishStrategy(mediator.Sequential))
+	for range 2 {
+		if err := mediator.Publish(manager, context.Background(), PaymentReceived{ID: "1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if first.Load() != 1 || second.Load() != 1 {
+		t.Fatalf("expected each handler called once, got %d and %d", first.Load(), second.Load())
+	}
+}
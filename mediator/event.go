@@ -2,11 +2,17 @@ package mediator
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"slices"
+
+	"github.com/oesand/octo"
 )
 
 // EventHandler defines a contract for handling notifications of type TEvent.
 // Unlike requests, notifications do not return responses; instead, they are "fire-and-forget".
+//
+// If TEvent is an interface, handler receives every event implementing it.
 type EventHandler[TEvent any] interface {
 	Notification(ctx context.Context, event TEvent) error
 }
@@ -14,49 +20,142 @@ type EventHandler[TEvent any] interface {
 // MassEventHandler is an adapter for handlers that can process events
 // of multiple concrete types. It exposes the event types the handler
 // accepts and a generic Handle method invoked for matching events.
+//
+// Interface event types match every event implementing them,
+// use [AllEvents] to receive all published events.
 type MassEventHandler interface {
 	EventTypes() []reflect.Type
 	Handle(ctx context.Context, event any) error
 }
 
+// AllEvents is an event type matching every published event.
+var AllEvents = reflect.TypeFor[any]()
+
 // Publish publishes a event to all registered NotificationHandlers.
-// The event is sent to every matching handler until either:
-//   - The context is canceled, or
+// Handlers are executed by the manager [PublishStrategy], which can be
+// overridden for a single call with [WithStrategy].
+// A single matching handler is called on the caller goroutine.
+// With [WithTransport] the event is also forwarded to the remote service if the transport accepts it.
+// With [WithScope] handlers are resolved from a scope created for the call,
+// [FireAndForget] strategy is not supported with it.
+// Handlers registered with [octo.InjectScoped] are called only within a scope,
+// fire-and-forget handlers don't use the scope of the caller.
+//
+// With default strategy the event is sent to every matching handler until either:
+//   - The context is canceled,
+//   - Any handler returned an error, or
 //   - All handlers have been executed.
 func Publish(
 	manager *Manager,
 	ctx context.Context,
 	event any,
+	options ...CallOption,
 ) error {
-	manager.ensureInit()
-
-	handlers, has := manager.handlers[reflect.TypeOf(event)]
-	if !has {
+	table := manager.dispatch()
+	eventType := reflect.TypeOf(event)
+	handlers := table.handlersFor(eventType)
+	if table.remote != nil && manager.transport.Accepts(eventType) {
+		handlers = append(slices.Clip(handlers), *table.remote)
+	}
+	if len(handlers) == 0 {
 		return nil
 	}
 
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	results := make(chan error, len(handlers))
-	for _, handle := range handlers {
-		go func() {
-			results <- handle(ctx, event)
-		}()
+	opts := manager.callOptions(options)
+	if opts.scope != nil {
+		return publishInScope(manager, ctx, event, handlers, opts)
 	}
+	if opts.strategy == FireAndForget && Scope(ctx) != nil {
+		// Handlers outlive the scope of the caller, so they don't use it
+		ctx = withoutScope(ctx)
+	}
+	if Scope(ctx) == nil {
+		if handlers = unscopedHandlers(handlers); len(handlers) == 0 {
+			return nil
+		}
+	}
+	return publishTo(ctx, event, handlers, opts.strategy, runTasks)
+}
 
-	defer close(results)
+// publishInScope is separated so the scope disposal doesn't cost calls without scope.
+func publishInScope(manager *Manager, ctx context.Context, event any, handlers []eventHandler, opts callOptions) error {
+	if opts.strategy == FireAndForget {
+		return errors.New("mediator: fire-and-forget strategy is not supported with scope")
+	}
+	ctx, scope := manager.beginScope(ctx, opts.scope)
+	return endScope(scope, publishTo(ctx, event, handlers, opts.strategy, runTasksInScope))
+}
 
-	for i := 0; i < len(handlers); i++ {
-		select {
-		case <-ctx.Done():
+func publishTo(
+	ctx context.Context,
+	event any,
+	handlers []eventHandler,
+	strategy PublishStrategy,
+	run func(ctx context.Context, strategy PublishStrategy, tasks []task) error,
+) error {
+	if len(handlers) == 1 && strategy != FireAndForget {
+		if ctx.Err() != nil {
 			return context.Cause(ctx)
-		case err := <-results:
-			if err != nil {
-				return err
-			}
+		}
+		if err := handlers[0].handle(ctx, event); err != nil {
+			return &HandlerError{Handler: handlers[0].info, Err: err}
+		}
+		return nil
+	}
+
+	tasks := make([]task, len(handlers))
+	for i, handler := range handlers {
+		tasks[i] = task{
+			info: handler.info,
+			run: func(ctx context.Context) error {
+				return handler.handle(ctx, event)
+			},
 		}
 	}
 
-	return nil
+	return run(ctx, strategy, tasks)
+}
+
+// typedEventHandler is implemented by generic handler adapters
+// which are called by [Publish] without reflection.
+type typedEventHandler interface {
+	notify(ctx context.Context, event any) error
+}
+
+// EventHandlerFunc is an adapter to allow the use of ordinary functions as [EventHandler].
+type EventHandlerFunc[TEvent any] func(ctx context.Context, event TEvent) error
+
+func (f EventHandlerFunc[TEvent]) Notification(ctx context.Context, event TEvent) error {
+	return f(ctx, event)
+}
+
+func (f EventHandlerFunc[TEvent]) notify(ctx context.Context, event any) error {
+	return f(ctx, event.(TEvent))
+}
+
+// HandleEventFunc registers a function as EventHandler[TEvent] in the container.
+func HandleEventFunc[TEvent any](container *octo.Container, handler func(ctx context.Context, event TEvent) error) {
+	octo.InjectValue(container, EventHandlerFunc[TEvent](handler))
+}
+
+// InjectEventHandler registers a provider of EventHandler[TEvent] in the container.
+//
+// Unlike handlers injected directly, which [Publish] calls through reflection,
+// handlers injected this way are called directly and do not allocate on publish.
+func InjectEventHandler[TEvent any](container *octo.Container, provider octo.Provider[EventHandler[TEvent]]) {
+	octo.Inject(container, func(container *octo.Container) *eventHandlerAdapter[TEvent] {
+		return &eventHandlerAdapter[TEvent]{handler: provider(container)}
+	})
+}
+
+type eventHandlerAdapter[TEvent any] struct {
+	handler EventHandler[TEvent]
+}
+
+func (a *eventHandlerAdapter[TEvent]) Notification(ctx context.Context, event TEvent) error {
+	return a.handler.Notification(ctx, event)
+}
+
+func (a *eventHandlerAdapter[TEvent]) notify(ctx context.Context, event any) error {
+	return a.handler.Notification(ctx, event.(TEvent))
 }
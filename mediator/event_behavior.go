@@ -0,0 +1,85 @@
+package mediator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// HandlerInfo identifies an event handler registered in the container.
+type HandlerInfo struct {
+	// Type is the type of the handler declaration.
+	Type reflect.Type
+
+	// Name is the optional name of the handler declaration.
+	Name string
+
+	// ID uniquely identifies the handler within the manager. It equals [HandlerInfo.String]
+	// for the first declaration of the type and name and has "#n" suffix for the following ones,
+	// so it is stable while handlers are registered in the same order.
+	// Handlers added by [Subscribe] have a unique "#sub" suffix.
+	ID string
+}
+
+func (info HandlerInfo) String() string {
+	var typeName string
+	if info.Type != nil {
+		typeName = info.Type.String()
+	}
+	if info.Name == "" {
+		return typeName
+	}
+	return info.Name + "(" + typeName + ")"
+}
+
+// EventBehavior wraps every event handler invocation made by [Publish], like a middleware.
+// Behaviors are discovered from the container and can be used
+// for tracing, panic recovery or handler level timeouts.
+//
+// Behavior must call next to invoke the handler, or return without
+// calling it to skip the handler. Order of behaviors is controlled by [Ordered].
+type EventBehavior interface {
+	HandleEvent(ctx context.Context, handler HandlerInfo, event any, next func(ctx context.Context) error) error
+}
+
+// EventBehaviorFunc is an adapter to allow the use of ordinary functions as [EventBehavior].
+type EventBehaviorFunc func(ctx context.Context, handler HandlerInfo, event any, next func(ctx context.Context) error) error
+
+func (f EventBehaviorFunc) HandleEvent(ctx context.Context, handler HandlerInfo, event any, next func(ctx context.Context) error) error {
+	return f(ctx, handler, event, next)
+}
+
+func (t *dispatchTable) wrapHandler(info HandlerInfo, handle handleEvent) handleEvent {
+	for i := len(t.eventBehaviors) - 1; i >= 0; i-- {
+		behavior, inner := t.eventBehaviors[i], handle
+		handle = func(ctx context.Context, event any) error {
+			return behavior.HandleEvent(ctx, info, event, func(ctx context.Context) error {
+				return inner(ctx, event)
+			})
+		}
+	}
+	return handle
+}
+
+// Recovery returns an [EventBehavior] which converts handler panics into errors.
+func Recovery() EventBehavior {
+	return EventBehaviorFunc(func(ctx context.Context, handler HandlerInfo, event any, next func(ctx context.Context) error) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("mediator: handler %s panicked: %v", handler, r)
+			}
+		}()
+		return next(ctx)
+	})
+}
+
+// HandlerTimeout returns an [EventBehavior] which limits the duration of every handler call.
+// Handlers must respect context cancellation to be interrupted.
+func HandlerTimeout(timeout time.Duration) EventBehavior {
+	return EventBehaviorFunc(func(ctx context.Context, handler HandlerInfo, event any, next func(ctx context.Context) error) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return next(ctx)
+	})
+}
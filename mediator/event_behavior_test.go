@@ -0,0 +1,81 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+)
+
+type PanicHandler struct{}
+
+func (h *PanicHandler) Notification(ctx context.Context, e EventX) error {
+	panic("boom")
+}
+
+type SlowHandler struct{}
+
+func (h *SlowHandler) Notification(ctx context.Context, e EventX) error {
+	select {
+	case <-time.After(time.Second):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestPublish_EventBehaviorReceivesHandler(t *testing.T) {
+	var mu sync.Mutex
+	var handlers []string
+
+	container := octo.New()
+	octo.InjectNamedValue(container, "first", &EventHandlerX{})
+	octo.InjectValue(container, mediator.EventBehaviorFunc(
+		func(ctx context.Context, handler mediator.HandlerInfo, event any, next func(ctx context.Context) error) error {
+			if _, ok := event.(EventX); !ok {
+				t.Errorf("unexpected event: %T", event)
+			}
+			mu.Lock()
+			handlers = append(handlers, handler.String())
+			mu.Unlock()
+			return next(ctx)
+		}))
+	manager := mediator.Inject(container)
+
+	if err := mediator.Publish(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := strings.Join(handlers, ","); got != "first(*mediator_test.EventHandlerX)" {
+		t.Fatalf("unexpected handlers: %s", got)
+	}
+}
+
+func TestPublish_Recovery(t *testing.T) {
+	container := octo.New()
+	octo.InjectValue(container, &PanicHandler{})
+	octo.InjectValue(container, mediator.Recovery())
+	manager := mediator.Inject(container)
+
+	err := mediator.Publish(manager, context.Background(), EventX{})
+	if err == nil || !strings.Contains(err.Error(), "panicked: boom") {
+		t.Fatalf("expected panic error, got %v", err)
+	}
+}
+
+func TestPublish_HandlerTimeout(t *testing.T) {
+	container := octo.New()
+	octo.InjectValue(container, &SlowHandler{})
+	octo.InjectValue(container, mediator.HandlerTimeout(10*time.Millisecond))
+	manager := mediator.Inject(container)
+
+	err := mediator.Publish(manager, context.Background(), EventX{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+}
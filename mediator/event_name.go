@@ -2,35 +2,70 @@ package mediator
 
 import "reflect"
 
+// NamedEvent is implemented by events which define their own stable name.
+// The name is used by [EventName] instead of the reflected one,
+// so renaming or moving the Go type doesn't break persisted events.
+type NamedEvent interface {
+	EventName() string
+}
+
+var namedEventType = reflect.TypeFor[NamedEvent]()
+
 // AbsoluteEventName returns the "absolute" name of a type including:
 // 1. The package import path (PkgPath)
-// 2. The struct name
+// 2. The type name, with type arguments for generic instantiations
 // 3. The pointer levels (e.g., "*", "**", etc.)
 //
+// Any named type is supported, unnamed types like slices or maps panic.
+//
 // Examples:
 //   - type MyStruct struct{} in package "github.com/user/project/pkg"
-//   - AbsoluteEventName(MyStruct)       => "github.com/user/project/pkg/MyStruct"
-//   - AbsoluteEventName(*MyStruct)      => "*github.com/user/project/pkg/MyStruct"
-//   - AbsoluteEventName(**MyStruct)     => "**github.com/user/project/pkg/MyStruct"
+//   - AbsoluteEventName(MyStruct)       => "github.com/user/project/pkg.MyStruct"
+//   - AbsoluteEventName(*MyStruct)      => "*github.com/user/project/pkg.MyStruct"
+//   - AbsoluteEventName(**MyStruct)     => "**github.com/user/project/pkg.MyStruct"
+//   - AbsoluteEventName(Generic[int])   => "github.com/user/project/pkg.Generic[int]"
+//   - AbsoluteEventName(Generic[MyStruct]) =>
+//     "github.com/user/project/pkg.Generic[github.com/user/project/pkg.MyStruct]"
 func AbsoluteEventName(typ reflect.Type) string {
-	target := typ
-	var ptrLevel string
-	for target.Kind() == reflect.Pointer {
-		target = target.Elem()
-		ptrLevel += "*"
-	}
+	target, ptrLevel := derefType(typ)
 
-	if target.Kind() != reflect.Struct {
+	if target.Name() == "" {
 		panic("unsupported type for event: " + target.String())
 	}
 
+	// Name of generic instantiation already contains
+	// type arguments qualified with their package paths.
 	name := target.Name()
 	if pkg := target.PkgPath(); pkg != "" {
 		name = pkg + "." + name
 	}
-	if ptrLevel != "" {
-		name = ptrLevel + name
+
+	return ptrLevel + name
+}
+
+// EventName returns the name of the event type returned by its EventName method
+// if the type or pointer to it implements [NamedEvent], and [AbsoluteEventName] otherwise.
+// Pointer levels are kept as a prefix in both cases.
+//
+// EventName method is called on the zero value and must not depend on its fields.
+func EventName(typ reflect.Type) string {
+	target, ptrLevel := derefType(typ)
+
+	switch {
+	case target.Implements(namedEventType):
+		return ptrLevel + reflect.Zero(target).Interface().(NamedEvent).EventName()
+	case reflect.PointerTo(target).Implements(namedEventType):
+		return ptrLevel + reflect.New(target).Interface().(NamedEvent).EventName()
+	default:
+		return AbsoluteEventName(typ)
 	}
+}
 
-	return name
+func derefType(typ reflect.Type) (reflect.Type, string) {
+	var ptrLevel string
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+		ptrLevel += "*"
+	}
+	return typ, ptrLevel
 }
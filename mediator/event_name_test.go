@@ -9,6 +9,17 @@ import (
 
 type SimpleStruct struct{}
 type GenericStruct[T any] struct{}
+type PairStruct[K comparable, V any] struct{}
+type UserID string
+type Status int
+
+type RenamedEvent struct{ Name string }
+
+func (RenamedEvent) EventName() string { return "users.created" }
+
+type PointerNamedEvent struct{}
+
+func (*PointerNamedEvent) EventName() string { return "users.deleted" }
 
 func TestAbsoluteTypeName(t *testing.T) {
 	tests := []struct {
@@ -41,6 +52,26 @@ func TestAbsoluteTypeName(t *testing.T) {
 			typ:  reflect.TypeFor[*GenericStruct[*SimpleStruct]](),
 			want: "*github.com/oesand/octo/mediator_test.GenericStruct[*github.com/oesand/octo/mediator_test.SimpleStruct]",
 		},
+		{
+			name: "GenericStruct[int]",
+			typ:  reflect.TypeFor[GenericStruct[int]](),
+			want: "github.com/oesand/octo/mediator_test.GenericStruct[int]",
+		},
+		{
+			name: "PairStruct[string,[]SimpleStruct]",
+			typ:  reflect.TypeFor[PairStruct[string, []SimpleStruct]](),
+			want: "github.com/oesand/octo/mediator_test.PairStruct[string,[]github.com/oesand/octo/mediator_test.SimpleStruct]",
+		},
+		{
+			name: "NamedString",
+			typ:  reflect.TypeFor[UserID](),
+			want: "github.com/oesand/octo/mediator_test.UserID",
+		},
+		{
+			name: "*NamedInt",
+			typ:  reflect.TypeFor[*Status](),
+			want: "*github.com/oesand/octo/mediator_test.Status",
+		},
 	}
 
 	for _, tt := range tests {
@@ -51,3 +82,33 @@ func TestAbsoluteTypeName(t *testing.T) {
 		})
 	}
 }
+
+func TestAbsoluteTypeName_Unnamed(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for unnamed type")
+		}
+	}()
+	mediator.AbsoluteEventName(reflect.TypeFor[[]SimpleStruct]())
+}
+
+func TestEventName(t *testing.T) {
+	tests := []struct {
+		typ  reflect.Type
+		want string
+	}{
+		{reflect.TypeFor[SimpleStruct](), "github.com/oesand/octo/mediator_test.SimpleStruct"},
+		{reflect.TypeFor[RenamedEvent](), "users.created"},
+		{reflect.TypeFor[*RenamedEvent](), "*users.created"},
+		{reflect.TypeFor[PointerNamedEvent](), "users.deleted"},
+		{reflect.TypeFor[*PointerNamedEvent](), "*users.deleted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			if got := mediator.EventName(tt.typ); got != tt.want {
+				t.Errorf("EventName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
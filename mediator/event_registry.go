@@ -0,0 +1,147 @@
+package mediator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ErrUnknownEvent is returned when an event name or type is not registered in [EventRegistry].
+var ErrUnknownEvent = errors.New("mediator: unknown event")
+
+// Envelope carries an encoded event with its name and metadata,
+// so it can be persisted or sent across processes and decoded back by [EventRegistry.Decode].
+type Envelope struct {
+	Name      string            `json:"name"`
+	ID        string            `json:"id"`
+	Timestamp time.Time         `json:"timestamp"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Payload   []byte            `json:"payload"`
+}
+
+// EventRegistry maps stable event names computed by [EventName] to event types.
+// Additional names can be registered with [EventRegistry.Alias]
+// to decode events persisted before the type was renamed.
+type EventRegistry struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type
+	names map[reflect.Type]string
+}
+
+// NewEventRegistry creates a registry with given event types.
+func NewEventRegistry(types ...reflect.Type) *EventRegistry {
+	registry := &EventRegistry{
+		types: make(map[string]reflect.Type),
+		names: make(map[reflect.Type]string),
+	}
+	registry.Register(types...)
+	return registry
+}
+
+// RegisterEvent registers the event type TEvent in the registry.
+func RegisterEvent[TEvent any](registry *EventRegistry) {
+	registry.Register(reflect.TypeFor[TEvent]())
+}
+
+// Register registers event types in the registry.
+// Panics if other type is already registered with the same name.
+func (r *EventRegistry) Register(types ...reflect.Type) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, typ := range types {
+		name := EventName(typ)
+		r.add(name, typ)
+		r.names[typ] = name
+	}
+}
+
+// RegisterAs registers the event type with the name overriding the reflected one.
+// The name is used to encode events of the type.
+func (r *EventRegistry) RegisterAs(typ reflect.Type, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.add(name, typ)
+	r.names[typ] = name
+}
+
+// Alias registers additional names decoded into the event type,
+// events of the type are still encoded with its registered name.
+// Registers the type with [EventName] if it is not registered yet.
+func (r *EventRegistry) Alias(typ reflect.Type, aliases ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, alias := range aliases {
+		r.add(alias, typ)
+	}
+	if _, ok := r.names[typ]; !ok {
+		name := EventName(typ)
+		r.add(name, typ)
+		r.names[typ] = name
+	}
+}
+
+func (r *EventRegistry) add(name string, typ reflect.Type) {
+	if registered, ok := r.types[name]; ok && registered != typ {
+		panic(fmt.Sprintf("mediator: event name %q is already registered for %s", name, registered))
+	}
+	r.types[name] = typ
+}
+
+// Name returns the registered name of the event type.
+func (r *EventRegistry) Name(typ reflect.Type) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	name, ok := r.names[typ]
+	return name, ok
+}
+
+// Type returns the event type registered with the name.
+func (r *EventRegistry) Type(name string) (reflect.Type, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	typ, ok := r.types[name]
+	return typ, ok
+}
+
+// Encode marshals the registered event into an envelope with a new id and the current time.
+func (r *EventRegistry) Encode(codec Codec, event any, metadata map[string]string) (Envelope, error) {
+	name, ok := r.Name(reflect.TypeOf(event))
+	if !ok {
+		return Envelope{}, fmt.Errorf("%w: %T", ErrUnknownEvent, event)
+	}
+
+	payload, err := codec.Marshal(event)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	return Envelope{
+		Name:      name,
+		ID:        newMessageID(),
+		Timestamp: time.Now(),
+		Metadata:  metadata,
+		Payload:   payload,
+	}, nil
+}
+
+// Decode unmarshals the envelope payload into a value of the registered event type.
+// The result has the same type as the encoded event and can be passed to [Publish].
+func (r *EventRegistry) Decode(codec Codec, envelope Envelope) (any, error) {
+	typ, ok := r.Type(envelope.Name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, envelope.Name)
+	}
+
+	value := reflect.New(typ)
+	if err := codec.Unmarshal(envelope.Payload, value.Interface()); err != nil {
+		return nil, err
+	}
+	return value.Elem().Interface(), nil
+}
@@ -0,0 +1,120 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+)
+
+func TestEventRegistry_Names(t *testing.T) {
+	registry := mediator.NewEventRegistry(reflect.TypeFor[EventX]())
+	mediator.RegisterEvent[*EventX](registry)
+
+	name, ok := registry.Name(reflect.TypeFor[*EventX]())
+	if !ok || name != "*github.com/oesand/octo/mediator_test.EventX" {
+		t.Fatalf("unexpected name: %q", name)
+	}
+	if typ, ok := registry.Type("github.com/oesand/octo/mediator_test.EventX"); !ok || typ != reflect.TypeFor[EventX]() {
+		t.Fatalf("unexpected type: %v", typ)
+	}
+	if _, ok := registry.Type("unknown"); ok {
+		t.Fatal("expected unknown name not found")
+	}
+}
+
+func TestEventRegistry_Codecs(t *testing.T) {
+	registry := mediator.NewEventRegistry(reflect.TypeFor[EventX](), reflect.TypeFor[*EventX]())
+	codecs := map[string]mediator.Codec{
+		"json": mediator.JSONCodec,
+		"gob":  mediator.GobCodec,
+	}
+
+	for name, codec := range codecs {
+		t.Run(name, func(t *testing.T) {
+			for _, event := range []any{EventX{Name: "value"}, &EventX{Name: "pointer"}} {
+				envelope, err := registry.Encode(codec, event, map[string]string{"trace": "1"})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if envelope.ID == "" || envelope.Timestamp.IsZero() || envelope.Metadata["trace"] != "1" {
+					t.Fatalf("unexpected envelope: %+v", envelope)
+				}
+
+				decoded, err := registry.Decode(codec, envelope)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(decoded, event) {
+					t.Fatalf("expected %#v, got %#v", event, decoded)
+				}
+			}
+		})
+	}
+}
+
+func TestEventRegistry_Unknown(t *testing.T) {
+	registry := mediator.NewEventRegistry()
+
+	if _, err := registry.Encode(mediator.JSONCodec, EventX{}, nil); !errors.Is(err, mediator.ErrUnknownEvent) {
+		t.Fatalf("expected unknown event error, got %v", err)
+	}
+	if _, err := registry.Decode(mediator.JSONCodec, mediator.Envelope{Name: "unknown"}); !errors.Is(err, mediator.ErrUnknownEvent) {
+		t.Fatalf("expected unknown event error, got %v", err)
+	}
+}
+
+func TestEventRegistry_DecodeAndPublish(t *testing.T) {
+	container := octo.New()
+	h := &EventHandlerX{}
+	octo.InjectValue(container, h)
+	manager := mediator.Inject(container)
+	registry := mediator.NewEventRegistry(reflect.TypeFor[EventX]())
+
+	envelope, _ := registry.Encode(mediator.JSONCodec, EventX{}, nil)
+	event, err := registry.Decode(mediator.JSONCodec, envelope)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = mediator.Publish(manager, context.Background(), event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !h.Called.Load() {
+		t.Fatal("expected handler called with decoded event")
+	}
+}
+
+func TestEventRegistry_NameOverrides(t *testing.T) {
+	registry := mediator.NewEventRegistry(reflect.TypeFor[RenamedEvent]())
+	registry.RegisterAs(reflect.TypeFor[EventX](), "x.v2")
+	registry.Alias(reflect.TypeFor[EventX](), "x.v1")
+
+	if name, _ := registry.Name(reflect.TypeFor[RenamedEvent]()); name != "users.created" {
+		t.Fatalf("expected EventName method used, got %q", name)
+	}
+	if name, _ := registry.Name(reflect.TypeFor[EventX]()); name != "x.v2" {
+		t.Fatalf("expected registered name used, got %q", name)
+	}
+
+	payload, _ := mediator.JSONCodec.Marshal(EventX{Name: "old"})
+	event, err := registry.Decode(mediator.JSONCodec, mediator.Envelope{Name: "x.v1", Payload: payload})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.(EventX).Name != "old" {
+		t.Fatalf("unexpected event: %#v", event)
+	}
+}
+
+func TestEventRegistry_NameConflict(t *testing.T) {
+	registry := mediator.NewEventRegistry(reflect.TypeFor[RenamedEvent]())
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for conflicting name")
+		}
+	}()
+	registry.Alias(reflect.TypeFor[EventX](), "users.created")
+}
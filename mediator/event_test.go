@@ -3,6 +3,7 @@ package mediator_test
 import (
 	"context"
 	"errors"
+	"reflect"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -87,3 +88,120 @@ func TestPublish_MultipleHandlersCancelled(t *testing.T) {
 		t.Fatal("expected both handlers called")
 	}
 }
+
+type DomainEvent interface {
+	Aggregate() string
+}
+
+type UserCreated struct{}
+
+func (UserCreated) Aggregate() string { return "user" }
+
+type DomainEventHandler struct {
+	Events atomic.Int32
+}
+
+func (h *DomainEventHandler) Notification(ctx context.Context, e DomainEvent) error {
+	h.Events.Add(1)
+	return nil
+}
+
+type AllEventsHandler struct {
+	Events atomic.Int32
+}
+
+func (h *AllEventsHandler) EventTypes() []reflect.Type {
+	return []reflect.Type{mediator.AllEvents, reflect.TypeFor[EventX]()}
+}
+
+func (h *AllEventsHandler) Handle(ctx context.Context, event any) error {
+	h.Events.Add(1)
+	return nil
+}
+
+func TestPublish_InterfaceHandler(t *testing.T) {
+	container := octo.New()
+	h := &DomainEventHandler{}
+	octo.InjectValue(container, h)
+	manager := mediator.Inject(container)
+
+	for range 2 {
+		if err := mediator.Publish(manager, context.Background(), UserCreated{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := mediator.Publish(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := h.Events.Load(); n != 2 {
+		t.Fatalf("expected 2 domain events, got %d", n)
+	}
+}
+
+func TestPublish_AllEventsHandlerOncePerEvent(t *testing.T) {
+	container := octo.New()
+	h := &AllEventsHandler{}
+	octo.InjectValue(container, h)
+	manager := mediator.Inject(container)
+
+	for _, event := range []any{UserCreated{}, EventX{}, &EventX{}} {
+		if err := mediator.Publish(manager, context.Background(), event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if n := h.Events.Load(); n != 3 {
+		t.Fatalf("expected 3 events, got %d", n)
+	}
+}
+
+func TestPublish_HandleEventFunc(t *testing.T) {
+	container := octo.New()
+	var called atomic.Bool
+	mediator.HandleEventFunc(container, func(ctx context.Context, event EventX) error {
+		called.Store(event.Name == "func")
+		return nil
+	})
+	manager := mediator.Inject(container)
+
+	if err := mediator.Publish(manager, context.Background(), EventX{Name: "func"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called.Load() {
+		t.Fatal("expected function handler called")
+	}
+}
+
+type CountingHandler struct {
+	Events atomic.Int32
+}
+
+func (h *CountingHandler) Notification(ctx context.Context, e *EventX) error {
+	h.Events.Add(1)
+	return nil
+}
+
+func TestPublish_InjectEventHandlerAllocationFree(t *testing.T) {
+	container := octo.New()
+	h := &CountingHandler{}
+	mediator.InjectEventHandler(container, func(c *octo.Container) mediator.EventHandler[*EventX] {
+		return h
+	})
+	manager := mediator.Inject(container)
+
+	ctx := context.Background()
+	var event any = &EventX{Name: "typed"}
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := mediator.Publish(manager, ctx, event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+	if h.Events.Load() == 0 {
+		t.Fatal("expected handler called")
+	}
+}
@@ -0,0 +1,45 @@
+package mediator
+
+import (
+	"encoding/gob"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// loadGobFile decodes items saved by writeGobFile, missing file has no items.
+func loadGobFile[T any](path string) ([]T, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var items []T
+	if err = gob.NewDecoder(file).Decode(&items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// writeGobFile replaces the file content atomically with the encoded items,
+// through a temporary file renamed over it.
+func writeGobFile[T any](path string, items []T) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err = gob.NewEncoder(tmp).Encode(items); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
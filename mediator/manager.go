@@ -3,15 +3,18 @@ package mediator
 import (
 	"context"
 	"reflect"
+	"strconv"
 	"sync"
+	"sync/atomic"
 
 	"github.com/oesand/octo"
+	"github.com/oesand/octo/internal"
 )
 
 // Inject injects a Manager into the container if not already registered.
 //
 // Options will be applied in any case.
-func Inject(container *octo.Container) *Manager {
+func Inject(container *octo.Container, options ...Option) *Manager {
 	manager := octo.TryResolve[*Manager](container)
 	if manager != nil {
 		if manager.container == nil {
@@ -23,15 +26,68 @@ func Inject(container *octo.Container) *Manager {
 		}
 		octo.InjectValue(container, manager)
 	}
+
+	for _, option := range options {
+		option(manager)
+	}
 	return manager
 }
 
 type handleEvent func(ctx context.Context, event any) error
 
+type eventHandler struct {
+	id     int
+	info   HandlerInfo
+	handle handleEvent
+	scoped bool
+}
+
+type registration struct {
+	eventType reflect.Type
+	handler   eventHandler
+}
+
 type Manager struct {
 	onceInit  sync.Once
 	container *octo.Container
-	handlers  map[reflect.Type][]handleEvent
+	strategy  PublishStrategy
+	transport Transport
+
+	mu            sync.Mutex
+	lastID        int
+	scanned       scanResult
+	subscriptions []registration
+	table         atomic.Pointer[dispatchTable]
+
+	asyncOnce         sync.Once
+	async             *asyncQueue
+	asyncWorkers      int
+	asyncCapacity     int
+	asyncPolicy       BackpressurePolicy
+	asyncErrorHandler func(ctx context.Context, event any, err error)
+
+	scheduleMu    sync.Mutex
+	schedule      *scheduler
+	scheduleDone  bool
+	scheduleStore ScheduleStore
+	clock         Clock
+}
+
+type scanResult struct {
+	handlers       []registration
+	behaviors      []PipelineBehavior
+	eventBehaviors []EventBehavior
+}
+
+// dispatchTable is an immutable snapshot of handlers and behaviors
+// used by calls, it is replaced entirely when handlers change.
+type dispatchTable struct {
+	handlers       map[reflect.Type][]eventHandler
+	ifaceTypes     []reflect.Type
+	matched        sync.Map
+	behaviors      []PipelineBehavior
+	eventBehaviors []EventBehavior
+	remote         *eventHandler
 }
 
 func (m *Manager) ensureInit() {
@@ -44,46 +100,243 @@ func (m *Manager) ensureInit() {
 	m.onceInit.Do(m.doInit)
 }
 
+func (m *Manager) dispatch() *dispatchTable {
+	m.ensureInit()
+	return m.table.Load()
+}
+
 var (
 	ctxType   = reflect.TypeFor[context.Context]()
 	errorType = reflect.TypeFor[error]()
 )
 
 func (m *Manager) doInit() {
-	if m.handlers == nil {
-		m.handlers = make(map[reflect.Type][]handleEvent)
-	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.scan()
+	m.rebuild()
+}
+
+// Refresh scans the container again to discover handlers and behaviors
+// injected after the first call of the manager.
+// Subscriptions made with [Subscribe] are kept.
+func (m *Manager) Refresh() {
+	m.ensureInit()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.scan()
+	m.rebuild()
+}
+
+func (m *Manager) nextID() int {
+	m.lastID++
+	return m.lastID
+}
+
+func (m *Manager) scan() {
+	var result scanResult
 
 	massHandlerType := reflect.TypeFor[MassEventHandler]()
+	behaviorType := reflect.TypeFor[PipelineBehavior]()
+	eventBehaviorType := reflect.TypeFor[EventBehavior]()
+	declared := make(map[HandlerInfo]int)
 	injects := octo.ResolveInjections(m.container)
 	for decl := range injects {
-		if method, ok := decl.Type().MethodByName("Notification"); ok &&
-			method.Type.NumIn() == 3 && method.Type.In(1).AssignableTo(ctxType) &&
-			method.Type.NumOut() == 1 && method.Type.Out(0).AssignableTo(errorType) {
-
-			eventType := method.Type.In(2)
-			m.handlers[eventType] = append(m.handlers[eventType], func(ctx context.Context, event any) error {
-				handler := decl.Value()
-				values := []reflect.Value{
-					reflect.ValueOf(handler),
-					reflect.ValueOf(ctx),
-					reflect.ValueOf(event),
-				}
-				err := method.Func.Call(values)[0].Interface()
-				if err != nil {
-					return err.(error)
-				}
-				return nil
+		// Scoped behaviors and mass handlers can't be shared by calls, so they are skipped.
+		scoped := octo.IsScoped(decl)
+		if decl.Type().Implements(behaviorType) && !scoped {
+			result.behaviors = append(result.behaviors, decl.Value().(PipelineBehavior))
+		}
+		if decl.Type().Implements(eventBehaviorType) && !scoped {
+			result.eventBehaviors = append(result.eventBehaviors, decl.Value().(EventBehavior))
+		}
+
+		id := m.nextID()
+		info := HandlerInfo{Type: decl.Type(), Name: decl.Name()}
+		index := declared[info]
+		declared[info]++
+		info.ID = handlerID(info, index)
+
+		if eventType, ok := notificationEventType(decl.Type()); ok {
+			result.handlers = append(result.handlers, registration{
+				eventType: eventType,
+				handler: eventHandler{
+					id:     id,
+					info:   info,
+					handle: notificationHandle(decl),
+					scoped: scoped,
+				},
 			})
 			continue
 		}
-		if decl.Type().Implements(massHandlerType) {
+		if decl.Type().Implements(massHandlerType) && !scoped {
 			handler := decl.Value().(MassEventHandler)
 			for _, eventType := range handler.EventTypes() {
-				m.handlers[eventType] = append(m.handlers[eventType], func(ctx context.Context, event any) error {
-					return handler.Handle(ctx, event)
+				result.handlers = append(result.handlers, registration{
+					eventType: eventType,
+					handler: eventHandler{
+						id:     id,
+						info:   info,
+						handle: handler.Handle,
+					},
 				})
 			}
 		}
 	}
+
+	sortByOrder(result.behaviors)
+	sortByOrder(result.eventBehaviors)
+	m.scanned = result
+}
+
+func handlerID(info HandlerInfo, index int) string {
+	if index == 0 {
+		return info.String()
+	}
+	return info.String() + "#" + strconv.Itoa(index)
+}
+
+// methodParams returns parameter and result types of the method
+// excluding the receiver, which is present only for non-interface types.
+func methodParams(typ reflect.Type, name string) (in []reflect.Type, out []reflect.Type, ok bool) {
+	method, ok := typ.MethodByName(name)
+	if !ok {
+		return nil, nil, false
+	}
+
+	first := 1
+	if typ.Kind() == reflect.Interface {
+		first = 0
+	}
+	for i := first; i < method.Type.NumIn(); i++ {
+		in = append(in, method.Type.In(i))
+	}
+	for i := 0; i < method.Type.NumOut(); i++ {
+		out = append(out, method.Type.Out(i))
+	}
+	return in, out, true
+}
+
+// notificationEventType returns the event type of the handler Notification method
+// if the method has signature Notification(context.Context, TEvent) error.
+func notificationEventType(typ reflect.Type) (reflect.Type, bool) {
+	in, out, ok := methodParams(typ, "Notification")
+	if !ok || len(in) != 2 || !in[0].AssignableTo(ctxType) ||
+		len(out) != 1 || !out[0].AssignableTo(errorType) {
+		return nil, false
+	}
+	return in[1], true
+}
+
+// notificationHandle calls Notification method of the handler declaration.
+// Handlers created by generic adapters are called directly,
+// other handlers are called through reflection.
+func notificationHandle(decl octo.Declaration) handleEvent {
+	var method reflect.Method
+	if decl.Type().Kind() != reflect.Interface {
+		method, _ = decl.Type().MethodByName("Notification")
+	}
+
+	return func(ctx context.Context, event any) error {
+		value := declarationValue(ctx, decl)
+		if typed, ok := value.(typedEventHandler); ok {
+			return typed.notify(ctx, event)
+		}
+
+		handler := reflect.ValueOf(value)
+
+		var err any
+		if method.Func.IsValid() {
+			values := []reflect.Value{
+				handler,
+				reflect.ValueOf(ctx),
+				reflect.ValueOf(event),
+			}
+			err = method.Func.Call(values)[0].Interface()
+		} else {
+			values := []reflect.Value{
+				reflect.ValueOf(ctx),
+				reflect.ValueOf(event),
+			}
+			err = handler.MethodByName("Notification").Call(values)[0].Interface()
+		}
+
+		if err != nil {
+			return err.(error)
+		}
+		return nil
+	}
+}
+
+func (m *Manager) rebuild() {
+	table := &dispatchTable{
+		handlers:       make(map[reflect.Type][]eventHandler),
+		behaviors:      m.scanned.behaviors,
+		eventBehaviors: m.scanned.eventBehaviors,
+	}
+
+	for _, reg := range m.scanned.handlers {
+		table.addHandler(reg)
+	}
+	for _, reg := range m.subscriptions {
+		table.addHandler(reg)
+	}
+	if m.transport != nil {
+		remote := remoteEventHandler(m.transport)
+		remote.handle = table.wrapHandler(remote.info, remote.handle)
+		table.remote = &remote
+	}
+
+	m.table.Store(table)
+}
+
+func (t *dispatchTable) addHandler(reg registration) {
+	if reg.eventType == nil {
+		return
+	}
+
+	handler := reg.handler
+	handler.handle = t.wrapHandler(handler.info, handler.handle)
+
+	handlers, ok := t.handlers[reg.eventType]
+	if !ok && reg.eventType.Kind() == reflect.Interface {
+		t.ifaceTypes = append(t.ifaceTypes, reg.eventType)
+	}
+	t.handlers[reg.eventType] = append(handlers, handler)
+}
+
+// handlersFor returns handlers registered for the exact event type followed by
+// handlers registered for interfaces the event type implements.
+// Every handler is returned at most once, matches are cached per event type.
+func (t *dispatchTable) handlersFor(eventType reflect.Type) []eventHandler {
+	if eventType == nil {
+		return nil
+	}
+	if cached, ok := t.matched.Load(eventType); ok {
+		return cached.([]eventHandler)
+	}
+
+	var matched []eventHandler
+	var seen internal.Set[int]
+	add := func(handlers []eventHandler) {
+		for _, handler := range handlers {
+			if !seen.Has(handler.id) {
+				seen.Add(handler.id)
+				matched = append(matched, handler)
+			}
+		}
+	}
+
+	add(t.handlers[eventType])
+	for _, ifaceType := range t.ifaceTypes {
+		if ifaceType != eventType && eventType.Implements(ifaceType) {
+			add(t.handlers[ifaceType])
+		}
+	}
+
+	t.matched.Store(eventType, matched)
+	return matched
 }
@@ -0,0 +1,63 @@
+package mediatortest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// AssertPublished fails the test if no event of type T was published and returns published events of the type.
+func AssertPublished[T any](t testing.TB, r *Recorder) []T {
+	t.Helper()
+
+	events := Published[T](r)
+	if len(events) == 0 {
+		t.Fatalf("expected %s published, got %d other events", reflect.TypeFor[T](), len(r.Events()))
+	}
+	return events
+}
+
+// AssertNotPublished fails the test if any event of type T was published.
+func AssertNotPublished[T any](t testing.TB, r *Recorder) {
+	t.Helper()
+
+	if events := Published[T](r); len(events) != 0 {
+		t.Fatalf("expected %s not published, got %d", reflect.TypeFor[T](), len(events))
+	}
+}
+
+// AssertSent fails the test if no request of type T was sent and returns sent requests of the type.
+func AssertSent[T any](t testing.TB, r *Recorder) []T {
+	t.Helper()
+
+	requests := Sent[T](r)
+	if len(requests) == 0 {
+		t.Fatalf("expected %s sent, got %d other requests", reflect.TypeFor[T](), len(r.Requests()))
+	}
+	return requests
+}
+
+// WaitFor waits until an event of type T is published and returns the first one.
+// Events published before the call are taken into account.
+// Fails the test if no event is published within the timeout.
+func WaitFor[T any](t testing.TB, r *Recorder, timeout time.Duration) T {
+	t.Helper()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		records, changed := r.watch()
+		if events := filter[T](records); len(events) != 0 {
+			return events[0]
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			t.Fatalf("expected %s published within %s", reflect.TypeFor[T](), timeout)
+			var zero T
+			return zero
+		}
+	}
+}
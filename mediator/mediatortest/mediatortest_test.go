@@ -0,0 +1,110 @@
+package mediatortest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+	"github.com/oesand/octo/mediator/mediatortest"
+)
+
+type PlaceOrder struct {
+	mediator.Request[int]
+	Item string
+}
+
+type OrderPlaced struct {
+	ID int
+}
+
+type OrderShipped struct{}
+
+type ctxKey struct{}
+
+var errStub = errors.New("stub failed")
+
+func TestRecorder_Requests(t *testing.T) {
+	container := octo.New()
+	stub := mediatortest.StubRequest[PlaceOrder](container, 42, nil)
+	recorder := mediatortest.NewRecorder(container)
+	manager := mediator.Inject(container)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "trace")
+	id, err := mediator.Send(manager, ctx, PlaceOrder{Item: "book"})
+	if err != nil || id != 42 {
+		t.Fatalf("unexpected response: %d %v", id, err)
+	}
+
+	sent := mediatortest.AssertSent[PlaceOrder](t, recorder)
+	if sent[0].Item != "book" {
+		t.Fatalf("unexpected request: %+v", sent[0])
+	}
+	if v := recorder.Requests()[0].Ctx.Value(ctxKey{}); v != "trace" {
+		t.Fatalf("expected context values recorded, got %v", v)
+	}
+	if calls := stub.Calls(); len(calls) != 1 {
+		t.Fatalf("expected 1 stub call, got %d", len(calls))
+	}
+
+	stub.Returns(0, errStub)
+	if _, err = mediator.Send(manager, ctx, PlaceOrder{}); !errors.Is(err, errStub) {
+		t.Fatalf("expected stub error, got %v", err)
+	}
+
+	stub.Does(func(ctx context.Context, request PlaceOrder) (int, error) {
+		return len(request.Item), nil
+	})
+	if id, err = mediator.Send(manager, ctx, PlaceOrder{Item: "pen"}); err != nil || id != 3 {
+		t.Fatalf("expected function response, got %d %v", id, err)
+	}
+}
+
+func TestRecorder_Events(t *testing.T) {
+	container := octo.New()
+	stub := mediatortest.StubEvent[OrderPlaced](container, nil)
+	recorder := mediatortest.NewRecorder(container)
+	manager := mediator.Inject(container)
+
+	if err := mediator.Publish(manager, context.Background(), OrderPlaced{ID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events := mediatortest.AssertPublished[OrderPlaced](t, recorder)
+	if events[0].ID != 1 {
+		t.Fatalf("unexpected event: %+v", events[0])
+	}
+	mediatortest.AssertNotPublished[OrderShipped](t, recorder)
+	if calls := stub.Calls(); len(calls) != 1 {
+		t.Fatalf("expected 1 stub call, got %d", len(calls))
+	}
+
+	stub.Returns(errStub)
+	if err := mediator.Publish(manager, context.Background(), OrderPlaced{}); !errors.Is(err, errStub) {
+		t.Fatalf("expected stub error, got %v", err)
+	}
+
+	recorder.Reset()
+	mediatortest.AssertNotPublished[OrderPlaced](t, recorder)
+}
+
+func TestWaitFor(t *testing.T) {
+	container := octo.New()
+	recorder := mediatortest.NewRecorder(container)
+	manager := mediator.Inject(container)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		for _, event := range []any{OrderShipped{}, OrderPlaced{ID: 5}} {
+			if err := mediator.Publish(manager, context.Background(), event); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+	}()
+
+	if event := mediatortest.WaitFor[OrderPlaced](t, recorder, time.Second); event.ID != 5 {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+}
@@ -0,0 +1,116 @@
+// Package mediatortest provides utilities for testing code using mediator:
+// a recorder of published events and sent requests, assertions and stub handlers.
+package mediatortest
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+)
+
+// Record is an event or request captured by [Recorder] with the context it was passed with.
+type Record struct {
+	Ctx   context.Context
+	Value any
+	At    time.Time
+}
+
+// Recorder captures every event published and request sent by the manager of the container.
+type Recorder struct {
+	mu       sync.Mutex
+	events   []Record
+	requests []Record
+	changed  chan struct{}
+}
+
+// NewRecorder creates a recorder of the container manager.
+// Events are recorded by a subscription to all events,
+// requests are recorded by a [mediator.PipelineBehavior] injected into the container.
+func NewRecorder(container *octo.Container) *Recorder {
+	recorder := &Recorder{changed: make(chan struct{})}
+
+	octo.InjectValue(container, &requestRecorder{recorder: recorder})
+	manager := mediator.Inject(container)
+	manager.Refresh()
+	mediator.Subscribe(manager, func(ctx context.Context, event any) error {
+		recorder.record(&recorder.events, ctx, event)
+		return nil
+	})
+
+	return recorder
+}
+
+type requestRecorder struct {
+	recorder *Recorder
+}
+
+func (r *requestRecorder) Handle(ctx context.Context, request any, next func(ctx context.Context) (any, error)) (any, error) {
+	r.recorder.record(&r.recorder.requests, ctx, request)
+	return next(ctx)
+}
+
+func (r *Recorder) record(records *[]Record, ctx context.Context, value any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	*records = append(*records, Record{Ctx: ctx, Value: value, At: time.Now()})
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// Events returns recorded events in the order they were published.
+func (r *Recorder) Events() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.events)
+}
+
+// Requests returns recorded requests in the order they were sent.
+func (r *Recorder) Requests() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.requests)
+}
+
+// Reset removes all recorded events and requests.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = nil
+	r.requests = nil
+}
+
+// Published returns recorded events of type T.
+func Published[T any](r *Recorder) []T {
+	return filter[T](r.Events())
+}
+
+// Sent returns recorded requests of type T.
+func Sent[T any](r *Recorder) []T {
+	return filter[T](r.Requests())
+}
+
+func filter[T any](records []Record) []T {
+	var values []T
+	for _, record := range records {
+		if value, ok := record.Value.(T); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// watch returns recorded events and the channel closed on the next record.
+func (r *Recorder) watch() ([]Record, <-chan struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.events), r.changed
+}
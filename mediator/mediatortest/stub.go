@@ -0,0 +1,105 @@
+package mediatortest
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+)
+
+// StubRequestHandler is a [mediator.RequestHandler] returning programmed response and error
+// and recording received requests.
+type StubRequestHandler[TRequest mediator.Request[TResponse], TResponse any] struct {
+	mu       sync.Mutex
+	response TResponse
+	err      error
+	fn       func(ctx context.Context, request TRequest) (TResponse, error)
+	calls    []TRequest
+}
+
+// StubRequest injects a stub handler of TRequest returning the response and error into the container.
+func StubRequest[TRequest mediator.Request[TResponse], TResponse any](
+	container *octo.Container,
+	response TResponse,
+	err error,
+) *StubRequestHandler[TRequest, TResponse] {
+	stub := &StubRequestHandler[TRequest, TResponse]{response: response, err: err}
+	octo.InjectValue[mediator.RequestHandler[TRequest, TResponse]](container, stub)
+	return stub
+}
+
+// Returns changes the response and error returned by the stub.
+func (s *StubRequestHandler[TRequest, TResponse]) Returns(response TResponse, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.response, s.err, s.fn = response, err, nil
+}
+
+// Does makes the stub handle requests by the function.
+func (s *StubRequestHandler[TRequest, TResponse]) Does(fn func(ctx context.Context, request TRequest) (TResponse, error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fn = fn
+}
+
+// Calls returns requests received by the stub.
+func (s *StubRequestHandler[TRequest, TResponse]) Calls() []TRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.calls)
+}
+
+func (s *StubRequestHandler[TRequest, TResponse]) Request(ctx context.Context, request TRequest) (TResponse, error) {
+	s.mu.Lock()
+	s.calls = append(s.calls, request)
+	response, err, fn := s.response, s.err, s.fn
+	s.mu.Unlock()
+
+	if fn != nil {
+		return fn(ctx, request)
+	}
+	return response, err
+}
+
+// StubEventHandler is a [mediator.EventHandler] returning programmed error and recording received events.
+type StubEventHandler[TEvent any] struct {
+	mu     sync.Mutex
+	err    error
+	events []TEvent
+}
+
+// StubEvent injects a stub handler of TEvent returning the error into the container.
+func StubEvent[TEvent any](container *octo.Container, err error) *StubEventHandler[TEvent] {
+	stub := &StubEventHandler[TEvent]{err: err}
+	octo.InjectValue[mediator.EventHandler[TEvent]](container, stub)
+	return stub
+}
+
+// Returns changes the error returned by the stub.
+func (s *StubEventHandler[TEvent]) Returns(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
+
+// Calls returns events received by the stub.
+func (s *StubEventHandler[TEvent]) Calls() []TEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.events)
+}
+
+func (s *StubEventHandler[TEvent]) Notification(ctx context.Context, event TEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, event)
+	return s.err
+}
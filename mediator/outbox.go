@@ -0,0 +1,249 @@
+package mediator
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/oesand/octo/internal"
+)
+
+const (
+	DefaultRelayInterval  = time.Second
+	DefaultRelayBatchSize = 100
+)
+
+// ErrNoOutbox is returned by [Enqueue] when the context has no outbox transaction.
+var ErrNoOutbox = errors.New("mediator: no outbox transaction in context")
+
+// OutboxMessage is an event recorded to the outbox and waiting for delivery.
+type OutboxMessage struct {
+	ID        string
+	Event     any
+	CreatedAt time.Time
+	Attempts  int
+	LastError string
+}
+
+// OutboxStore persists outbox messages until they are delivered.
+type OutboxStore interface {
+	// Save stores all messages or none of them.
+	Save(ctx context.Context, messages []OutboxMessage) error
+
+	// Pending returns up to limit undelivered messages in the order they were saved,
+	// skipping messages with maxAttempts or more failed attempts. Zero maxAttempts means no limit.
+	Pending(ctx context.Context, limit int, maxAttempts int) ([]OutboxMessage, error)
+
+	// Delivered removes delivered messages from the store.
+	Delivered(ctx context.Context, ids ...string) error
+
+	// Failed records a failed delivery attempt of the message.
+	Failed(ctx context.Context, id string, err error) error
+}
+
+var outboxCtxKey = internal.CtxKey{Key: "mediator/outbox"}
+
+// OutboxTx collects events recorded by [Enqueue] until it is committed.
+type OutboxTx struct {
+	store OutboxStore
+
+	mu       sync.Mutex
+	messages []OutboxMessage
+}
+
+// BeginOutbox starts an outbox transaction and returns the context
+// which must be passed to [Enqueue].
+//
+// Commit the transaction as a part of the business transaction,
+// e.g. with flow.Transactional(ctx, tx.Commit), so events are stored
+// only if the business transaction commits.
+func BeginOutbox(ctx context.Context, store OutboxStore) (context.Context, *OutboxTx) {
+	tx := &OutboxTx{store: store}
+	return context.WithValue(ctx, outboxCtxKey, tx), tx
+}
+
+// Enqueue records the event to the outbox transaction of the context.
+// The event is published by [OutboxRelay] after the transaction is committed.
+func Enqueue(ctx context.Context, event any) error {
+	tx, ok := ctx.Value(outboxCtxKey).(*OutboxTx)
+	if !ok {
+		return ErrNoOutbox
+	}
+
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	tx.messages = append(tx.messages, OutboxMessage{
+		ID:        newMessageID(),
+		Event:     event,
+		CreatedAt: time.Now(),
+	})
+	return nil
+}
+
+// Commit saves recorded events to the outbox store.
+// Events recorded after commit are saved by the next commit.
+func (tx *OutboxTx) Commit(ctx context.Context) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if len(tx.messages) == 0 {
+		return nil
+	}
+	if err := tx.store.Save(ctx, tx.messages); err != nil {
+		return err
+	}
+	tx.messages = nil
+	return nil
+}
+
+// Rollback discards recorded events.
+func (tx *OutboxTx) Rollback() {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	tx.messages = nil
+}
+
+func newMessageID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// RelayOption represents a function that modifies OutboxRelay configuration.
+type RelayOption func(*OutboxRelay)
+
+// WithRelayInterval sets the interval between polls of the outbox store,
+// non-positive interval means [DefaultRelayInterval].
+func WithRelayInterval(interval time.Duration) RelayOption {
+	return func(r *OutboxRelay) {
+		r.interval = interval
+	}
+}
+
+// WithRelayBatchSize sets the maximum number of messages dispatched by one poll,
+// non-positive size means [DefaultRelayBatchSize].
+func WithRelayBatchSize(size int) RelayOption {
+	return func(r *OutboxRelay) {
+		r.batchSize = size
+	}
+}
+
+// WithRelayRetries sets the number of immediate publish retries with the backoff between them.
+func WithRelayRetries(retries int, backoff time.Duration) RelayOption {
+	return func(r *OutboxRelay) {
+		r.retries = retries
+		r.backoff = backoff
+	}
+}
+
+// WithRelayMaxAttempts sets the number of failed attempts after which
+// the message is no longer returned as pending but kept in the store.
+func WithRelayMaxAttempts(attempts int) RelayOption {
+	return func(r *OutboxRelay) {
+		r.maxAttempts = attempts
+	}
+}
+
+// OutboxRelay dispatches messages from the outbox store by [Publish]
+// and removes them from the store once delivered.
+// Delivery is at-least-once, handlers must tolerate redelivered events.
+type OutboxRelay struct {
+	manager     *Manager
+	store       OutboxStore
+	interval    time.Duration
+	batchSize   int
+	retries     int
+	backoff     time.Duration
+	maxAttempts int
+}
+
+// NewOutboxRelay creates a relay of messages from the store to the manager.
+func NewOutboxRelay(manager *Manager, store OutboxStore, options ...RelayOption) *OutboxRelay {
+	relay := &OutboxRelay{
+		manager:   manager,
+		store:     store,
+		interval:  DefaultRelayInterval,
+		batchSize: DefaultRelayBatchSize,
+	}
+
+	for _, option := range options {
+		option(relay)
+	}
+	if relay.interval <= 0 {
+		relay.interval = DefaultRelayInterval
+	}
+	if relay.batchSize <= 0 {
+		relay.batchSize = DefaultRelayBatchSize
+	}
+
+	return relay
+}
+
+// Run dispatches pending messages every interval until the context is canceled.
+func (r *OutboxRelay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Flush(ctx); err != nil && ctx.Err() == nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush dispatches one batch of pending messages and returns the number of delivered ones.
+// Failed messages are recorded in the store and retried by the next flush.
+func (r *OutboxRelay) Flush(ctx context.Context) (int, error) {
+	messages, err := r.store.Pending(ctx, r.batchSize, r.maxAttempts)
+	if err != nil {
+		return 0, err
+	}
+
+	var delivered int
+	for _, message := range messages {
+		if err = r.publish(ctx, message); err != nil {
+			if ctx.Err() != nil {
+				return delivered, context.Cause(ctx)
+			}
+			if err = r.store.Failed(ctx, message.ID, err); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+
+		if err = r.store.Delivered(ctx, message.ID); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+	return delivered, nil
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, message OutboxMessage) error {
+	var err error
+	for attempt := 0; attempt <= r.retries; attempt++ {
+		if attempt > 0 && r.backoff > 0 {
+			select {
+			case <-ctx.Done():
+				return context.Cause(ctx)
+			case <-time.After(r.backoff):
+			}
+		}
+
+		if err = Publish(r.manager, ctx, message.Event); err == nil {
+			return nil
+		}
+	}
+	return err
+}
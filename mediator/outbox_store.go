@@ -0,0 +1,142 @@
+package mediator
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/oesand/octo/internal"
+)
+
+var _ OutboxStore = &MemoryOutboxStore{}
+
+// MemoryOutboxStore is an in-memory implementation of OutboxStore intended
+// primarily for tests and examples.
+type MemoryOutboxStore struct {
+	mu       sync.Mutex
+	messages []OutboxMessage
+}
+
+func (s *MemoryOutboxStore) Save(_ context.Context, messages []OutboxMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, messages...)
+	return nil
+}
+
+func (s *MemoryOutboxStore) Pending(_ context.Context, limit int, maxAttempts int) ([]OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return pendingMessages(s.messages, limit, maxAttempts), nil
+}
+
+func (s *MemoryOutboxStore) Delivered(_ context.Context, ids ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = removeMessages(s.messages, ids)
+	return nil
+}
+
+func (s *MemoryOutboxStore) Failed(_ context.Context, id string, err error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	failMessage(s.messages, id, err)
+	return nil
+}
+
+func pendingMessages(messages []OutboxMessage, limit int, maxAttempts int) []OutboxMessage {
+	var pending []OutboxMessage
+	for _, message := range messages {
+		if len(pending) >= limit {
+			break
+		}
+		if maxAttempts > 0 && message.Attempts >= maxAttempts {
+			continue
+		}
+		pending = append(pending, message)
+	}
+	return pending
+}
+
+func removeMessages(messages []OutboxMessage, ids []string) []OutboxMessage {
+	removed := internal.SetOf(ids...)
+	return slices.DeleteFunc(messages, func(message OutboxMessage) bool {
+		return removed.Has(message.ID)
+	})
+}
+
+func failMessage(messages []OutboxMessage, id string, err error) {
+	for i := range messages {
+		if messages[i].ID == id {
+			messages[i].Attempts++
+			messages[i].LastError = err.Error()
+			return
+		}
+	}
+}
+
+var _ OutboxStore = &FileOutboxStore{}
+
+// FileOutboxStore is an OutboxStore keeping messages in a local file encoded with [encoding/gob].
+// The file is rewritten atomically on every change, so the store
+// is suitable for small volumes of events in a single process.
+//
+// Concrete event types must be registered with [encoding/gob.Register].
+type FileOutboxStore struct {
+	path string
+
+	mu       sync.Mutex
+	messages []OutboxMessage
+}
+
+// NewFileOutboxStore opens the store at path, loading messages saved by previous runs.
+func NewFileOutboxStore(path string) (*FileOutboxStore, error) {
+	messages, err := loadGobFile[OutboxMessage](path)
+	if err != nil {
+		return nil, err
+	}
+	return &FileOutboxStore{path: path, messages: messages}, nil
+}
+
+func (s *FileOutboxStore) Save(_ context.Context, messages []OutboxMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.write(append(slices.Clip(s.messages), messages...))
+}
+
+func (s *FileOutboxStore) Pending(_ context.Context, limit int, maxAttempts int) ([]OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return pendingMessages(s.messages, limit, maxAttempts), nil
+}
+
+func (s *FileOutboxStore) Delivered(_ context.Context, ids ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.write(removeMessages(slices.Clone(s.messages), ids))
+}
+
+func (s *FileOutboxStore) Failed(_ context.Context, id string, err error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := slices.Clone(s.messages)
+	failMessage(messages, id, err)
+	return s.write(messages)
+}
+
+// write replaces the file content and the messages only if the file was written.
+func (s *FileOutboxStore) write(messages []OutboxMessage) error {
+	if err := writeGobFile(s.path, messages); err != nil {
+		return err
+	}
+	s.messages = messages
+	return nil
+}
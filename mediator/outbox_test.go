@@ -0,0 +1,204 @@
+package mediator_test
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+)
+
+func init() {
+	gob.Register(EventX{})
+}
+
+func TestEnqueue_NoOutbox(t *testing.T) {
+	if err := mediator.Enqueue(context.Background(), EventX{}); !errors.Is(err, mediator.ErrNoOutbox) {
+		t.Fatalf("expected no outbox error, got %v", err)
+	}
+}
+
+func TestOutbox_CommitAndRelay(t *testing.T) {
+	container := octo.New()
+	var names []string
+	mediator.HandleEventFunc(container, func(ctx context.Context, e EventX) error {
+		names = append(names, e.Name)
+		return nil
+	})
+	manager := mediator.Inject(container)
+	store := &mediator.MemoryOutboxStore{}
+	relay := mediator.NewOutboxRelay(manager, store)
+
+	ctx, tx := mediator.BeginOutbox(context.Background(), store)
+	if err := mediator.Enqueue(ctx, EventX{Name: "first"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mediator.Enqueue(ctx, EventX{Name: "second"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n, _ := relay.Flush(ctx); n != 0 {
+		t.Fatalf("expected nothing delivered before commit, got %d", n)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	n, err := relay.Flush(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 || len(names) != 2 || names[0] != "first" || names[1] != "second" {
+		t.Fatalf("expected events delivered in order, got %d %v", n, names)
+	}
+	if pending, _ := store.Pending(ctx, 10, 0); len(pending) != 0 {
+		t.Fatalf("expected no pending messages, got %d", len(pending))
+	}
+}
+
+func TestOutbox_Rollback(t *testing.T) {
+	store := &mediator.MemoryOutboxStore{}
+	ctx, tx := mediator.BeginOutbox(context.Background(), store)
+	if err := mediator.Enqueue(ctx, EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tx.Rollback()
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pending, _ := store.Pending(ctx, 10, 0); len(pending) != 0 {
+		t.Fatalf("expected no pending messages, got %d", len(pending))
+	}
+}
+
+func TestOutboxRelay_Retries(t *testing.T) {
+	container := octo.New()
+	var calls atomic.Int32
+	mediator.HandleEventFunc(container, func(ctx context.Context, e EventX) error {
+		if calls.Add(1) < 3 {
+			return errHandler
+		}
+		return nil
+	})
+	manager := mediator.Inject(container)
+	store := &mediator.MemoryOutboxStore{}
+	ctx := context.Background()
+	if err := store.Save(ctx, []mediator.OutboxMessage{{ID: "1", Event: EventX{}}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	relay := mediator.NewOutboxRelay(manager, store, mediator.WithRelayRetries(1, 0))
+	if n, _ := relay.Flush(ctx); n != 0 {
+		t.Fatalf("expected message not delivered, got %d", n)
+	}
+	pending, _ := store.Pending(ctx, 10, 0)
+	if len(pending) != 1 || pending[0].Attempts != 1 || pending[0].LastError == "" {
+		t.Fatalf("expected failed attempt recorded, got %+v", pending)
+	}
+
+	if n, _ := relay.Flush(ctx); n != 1 {
+		t.Fatalf("expected message delivered by next flush, got %d", n)
+	}
+	if c := calls.Load(); c != 3 {
+		t.Fatalf("expected 3 calls, got %d", c)
+	}
+}
+
+func TestOutboxRelay_MaxAttempts(t *testing.T) {
+	container := octo.New()
+	h := &FailHandler{}
+	octo.InjectValue(container, h)
+	manager := mediator.Inject(container)
+	store := &mediator.MemoryOutboxStore{}
+	ctx := context.Background()
+	if err := store.Save(ctx, []mediator.OutboxMessage{{ID: "1", Event: EventX{}, Attempts: 2}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	relay := mediator.NewOutboxRelay(manager, store, mediator.WithRelayMaxAttempts(2))
+	if n, err := relay.Flush(ctx); err != nil || n != 0 {
+		t.Fatalf("expected nothing delivered, got %d %v", n, err)
+	}
+	if h.Called.Load() {
+		t.Fatal("expected message over max attempts skipped")
+	}
+}
+
+func TestOutboxRelay_ExhaustedDoNotStall(t *testing.T) {
+	container := octo.New()
+	h := &EventHandlerX{}
+	octo.InjectValue(container, h)
+	manager := mediator.Inject(container)
+	store := &mediator.MemoryOutboxStore{}
+	ctx := context.Background()
+	if err := store.Save(ctx, []mediator.OutboxMessage{
+		{ID: "1", Event: EventX{}, Attempts: 3},
+		{ID: "2", Event: EventX{}},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	relay := mediator.NewOutboxRelay(manager, store, mediator.WithRelayBatchSize(1), mediator.WithRelayMaxAttempts(3))
+	if n, err := relay.Flush(ctx); err != nil || n != 1 {
+		t.Fatalf("expected message behind exhausted one delivered, got %d %v", n, err)
+	}
+	if pending, _ := store.Pending(ctx, 10, 0); len(pending) != 1 || pending[0].ID != "1" {
+		t.Fatalf("expected exhausted message kept in store, got %+v", pending)
+	}
+}
+
+func TestOutboxRelay_InvalidBatchSize(t *testing.T) {
+	container := octo.New()
+	h := &EventHandlerX{}
+	octo.InjectValue(container, h)
+	manager := mediator.Inject(container)
+	store := &mediator.MemoryOutboxStore{}
+	ctx := context.Background()
+	if err := store.Save(ctx, []mediator.OutboxMessage{{ID: "1", Event: EventX{}}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	relay := mediator.NewOutboxRelay(manager, store, mediator.WithRelayBatchSize(0))
+	if n, err := relay.Flush(ctx); err != nil || n != 1 {
+		t.Fatalf("expected message delivered with default batch size, got %d %v", n, err)
+	}
+}
+
+func TestFileOutboxStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox")
+	ctx := context.Background()
+
+	store, err := mediator.NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Save(ctx, []mediator.OutboxMessage{
+		{ID: "1", Event: EventX{Name: "first"}},
+		{ID: "2", Event: EventX{Name: "second"}},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Delivered(ctx, "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Failed(ctx, "2", errHandler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reopened, err := mediator.NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pending, _ := reopened.Pending(ctx, 10, 0)
+	if len(pending) != 1 || pending[0].ID != "2" || pending[0].Attempts != 1 {
+		t.Fatalf("expected persisted failed message, got %+v", pending)
+	}
+	if e, ok := pending[0].Event.(EventX); !ok || e.Name != "second" {
+		t.Fatalf("expected decoded event, got %#v", pending[0].Event)
+	}
+}
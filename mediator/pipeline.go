@@ -0,0 +1,56 @@
+package mediator
+
+import (
+	"context"
+	"slices"
+)
+
+// PipelineBehavior wraps every [Send] call, like a middleware.
+// Behaviors are discovered from the container and can be used
+// for logging, validation, authorization, transactions or metrics.
+//
+// Behavior must call next to continue the pipeline, or return without
+// calling it to short-circuit the request.
+type PipelineBehavior interface {
+	Handle(ctx context.Context, request any, next func(ctx context.Context) (any, error)) (any, error)
+}
+
+// PipelineBehaviorFunc is an adapter to allow the use of ordinary functions as [PipelineBehavior].
+type PipelineBehaviorFunc func(ctx context.Context, request any, next func(ctx context.Context) (any, error)) (any, error)
+
+func (f PipelineBehaviorFunc) Handle(ctx context.Context, request any, next func(ctx context.Context) (any, error)) (any, error) {
+	return f(ctx, request, next)
+}
+
+// Ordered can be implemented by behaviors to control their position in the pipeline.
+// Behaviors with lower order wrap behaviors with higher order,
+// behaviors with equal order keep the order of [octo.ResolveInjections],
+// which groups declarations of the same type at the first registration of the type.
+// Behaviors without Order method have order 0.
+type Ordered interface {
+	Order() int
+}
+
+func orderOf(behavior any) int {
+	if ordered, ok := behavior.(Ordered); ok {
+		return ordered.Order()
+	}
+	return 0
+}
+
+func sortByOrder[T any](behaviors []T) {
+	slices.SortStableFunc(behaviors, func(a, b T) int {
+		return orderOf(a) - orderOf(b)
+	})
+}
+
+func (t *dispatchTable) runPipeline(ctx context.Context, request any, handle func(ctx context.Context) (any, error)) (any, error) {
+	next := handle
+	for i := len(t.behaviors) - 1; i >= 0; i-- {
+		behavior, inner := t.behaviors[i], next
+		next = func(ctx context.Context) (any, error) {
+			return behavior.Handle(ctx, request, inner)
+		}
+	}
+	return next(ctx)
+}
@@ -0,0 +1,96 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+)
+
+type TraceBehavior struct {
+	name  string
+	order int
+	trace *[]string
+}
+
+func (b *TraceBehavior) Order() int {
+	return b.order
+}
+
+func (b *TraceBehavior) Handle(ctx context.Context, request any, next func(ctx context.Context) (any, error)) (any, error) {
+	*b.trace = append(*b.trace, b.name+">")
+	resp, err := next(ctx)
+	*b.trace = append(*b.trace, "<"+b.name)
+	return resp, err
+}
+
+func TestSend_PipelineOrder(t *testing.T) {
+	var trace []string
+
+	container := octo.New()
+	octo.InjectValue(container, &TestRequestHandler{})
+	octo.InjectNamedValue(container, "inner", &TraceBehavior{name: "inner", order: 10, trace: &trace})
+	octo.InjectNamedValue(container, "outer", &TraceBehavior{name: "outer", order: -10, trace: &trace})
+	octo.InjectNamedValue(container, "middle", &TraceBehavior{name: "middle", trace: &trace})
+	manager := mediator.Inject(container)
+
+	resp, err := mediator.Send(manager, context.Background(), TestRequest{Value: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Result != 4 {
+		t.Fatalf("expected 4, got %d", resp.Result)
+	}
+
+	if got := strings.Join(trace, " "); got != "outer> middle> inner> <inner <middle <outer" {
+		t.Fatalf("unexpected pipeline order: %s", got)
+	}
+}
+
+func TestSend_PipelineEqualOrder(t *testing.T) {
+	var trace []string
+
+	container := octo.New()
+	octo.InjectValue(container, &TestRequestHandler{})
+	octo.InjectNamedValue(container, "first", &TraceBehavior{name: "first", trace: &trace})
+	octo.InjectValue(container, mediator.PipelineBehaviorFunc(func(ctx context.Context, request any, next func(ctx context.Context) (any, error)) (any, error) {
+		trace = append(trace, "func>")
+		return next(ctx)
+	}))
+	octo.InjectNamedValue(container, "second", &TraceBehavior{name: "second", trace: &trace})
+	manager := mediator.Inject(container)
+
+	if _, err := mediator.Send(manager, context.Background(), TestRequest{Value: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(trace, " "); got != "first> second> func> <second <first" {
+		t.Fatalf("unexpected pipeline order: %s", got)
+	}
+}
+
+func TestSend_PipelineShortCircuit(t *testing.T) {
+	validationErr := errors.New("invalid request")
+
+	container := octo.New()
+	handler := &TestRequestHandler{}
+	octo.InjectValue(container, handler)
+	octo.InjectValue(container, mediator.PipelineBehaviorFunc(
+		func(ctx context.Context, request any, next func(ctx context.Context) (any, error)) (any, error) {
+			if request.(TestRequest).Value < 0 {
+				return nil, validationErr
+			}
+			return next(ctx)
+		}))
+	manager := mediator.Inject(container)
+
+	_, err := mediator.Send(manager, context.Background(), TestRequest{Value: -1})
+	if !errors.Is(err, validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if handler.Called.Load() {
+		t.Fatal("expected handler not called")
+	}
+}
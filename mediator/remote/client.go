@@ -0,0 +1,119 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/oesand/octo/mediator"
+)
+
+var _ mediator.Transport = &Client{}
+
+// Error is returned by [Client] when the remote service responds with an error.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return "remote: " + strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode) + ": " + e.Message
+}
+
+// ClientOption represents a function that modifies Client configuration.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the HTTP client used to call the remote service, [http.DefaultClient] by default.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Client) {
+		c.client = client
+	}
+}
+
+// Client is a [mediator.Transport] calling the [Server] at the base url.
+//
+// Example:
+//
+//	client := remote.NewClient("http://users:8080/mediator", registry)
+//	manager := mediator.Inject(container, mediator.WithTransport(client))
+type Client struct {
+	url      string
+	registry *mediator.EventRegistry
+	client   *http.Client
+}
+
+// NewClient creates a client of the server at the base url.
+// Requests and events must be registered in the registry.
+func NewClient(url string, registry *mediator.EventRegistry, options ...ClientOption) *Client {
+	client := &Client{
+		url:      strings.TrimSuffix(url, "/"),
+		registry: registry,
+		client:   http.DefaultClient,
+	}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	return client
+}
+
+// Send sends the request to the server and decodes its response into the response pointer.
+func (c *Client) Send(ctx context.Context, request any, response any) error {
+	resp, err := c.post(ctx, "/send", request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+// Accepts reports whether the event type is registered in the client registry.
+func (c *Client) Accepts(eventType reflect.Type) bool {
+	_, ok := c.registry.Name(eventType)
+	return ok
+}
+
+// Publish publishes the event by the server.
+func (c *Client) Publish(ctx context.Context, event any) error {
+	resp, err := c.post(ctx, "/publish", event)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (c *Client) post(ctx context.Context, path string, value any) (*http.Response, error) {
+	envelope, err := c.registry.Encode(mediator.JSONCodec, value, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+
+		var body errorBody
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		return nil, &Error{StatusCode: resp.StatusCode, Message: body.Error}
+	}
+	return resp, nil
+}
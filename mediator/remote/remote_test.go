@@ -0,0 +1,130 @@
+package remote_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+	"github.com/oesand/octo/mediator/remote"
+)
+
+type GetUser struct {
+	mediator.Request[User]
+	ID int
+}
+
+type User struct {
+	ID   int
+	Name string
+}
+
+type DeleteUser struct {
+	mediator.Request[mediator.Empty]
+	ID int
+}
+
+type UserCreated struct {
+	ID int
+}
+
+var errNotFound = errors.New("user not found")
+
+func newRemote(t *testing.T) (*mediator.Manager, chan UserCreated) {
+	container := octo.New()
+	mediator.HandleFunc(container, func(ctx context.Context, q GetUser) (User, error) {
+		if q.ID != 1 {
+			return User{}, errNotFound
+		}
+		return User{ID: 1, Name: "admin"}, nil
+	})
+	created := make(chan UserCreated, 1)
+	mediator.HandleEventFunc(container, func(ctx context.Context, e UserCreated) error {
+		created <- e
+		return nil
+	})
+
+	server := remote.NewServer(mediator.Inject(container), mediator.NewEventRegistry(reflect.TypeFor[UserCreated]()))
+	remote.HandleRequest[GetUser](server)
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	registry := mediator.NewEventRegistry(
+		reflect.TypeFor[GetUser](),
+		reflect.TypeFor[DeleteUser](),
+		reflect.TypeFor[UserCreated](),
+	)
+	client := remote.NewClient(httpServer.URL, registry, remote.WithHTTPClient(httpServer.Client()))
+	return mediator.Inject(octo.New(), mediator.WithTransport(client)), created
+}
+
+func TestRemote_Send(t *testing.T) {
+	manager, _ := newRemote(t)
+
+	user, err := mediator.Send(manager, context.Background(), GetUser{ID: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Name != "admin" {
+		t.Fatalf("unexpected response: %+v", user)
+	}
+}
+
+func TestRemote_SendError(t *testing.T) {
+	manager, _ := newRemote(t)
+
+	_, err := mediator.Send(manager, context.Background(), GetUser{ID: 2})
+	var remoteErr *remote.Error
+	if !errors.As(err, &remoteErr) || remoteErr.StatusCode != http.StatusInternalServerError || remoteErr.Message != errNotFound.Error() {
+		t.Fatalf("expected remote handler error, got %v", err)
+	}
+
+	_, err = mediator.Send(manager, context.Background(), DeleteUser{ID: 1})
+	if !errors.As(err, &remoteErr) || remoteErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected not exposed error, got %v", err)
+	}
+}
+
+func TestRemote_Publish(t *testing.T) {
+	manager, created := newRemote(t)
+
+	if err := mediator.Publish(manager, context.Background(), UserCreated{ID: 7}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e := <-created; e.ID != 7 {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+}
+
+func TestRemote_LocalHandlerPreferred(t *testing.T) {
+	client := remote.NewClient("http://127.0.0.1:0", mediator.NewEventRegistry())
+	container := octo.New()
+	mediator.HandleFunc(container, func(ctx context.Context, q GetUser) (User, error) {
+		return User{Name: "local"}, nil
+	})
+	manager := mediator.Inject(container, mediator.WithTransport(client))
+
+	user, err := mediator.Send(manager, context.Background(), GetUser{})
+	if err != nil || user.Name != "local" {
+		t.Fatalf("expected local handler, got %+v %v", user, err)
+	}
+}
+
+func TestRemote_PublishUnregisteredLocal(t *testing.T) {
+	client := remote.NewClient("http://127.0.0.1:0", mediator.NewEventRegistry())
+	container := octo.New()
+	var called bool
+	mediator.HandleEventFunc(container, func(ctx context.Context, e UserCreated) error {
+		called = true
+		return nil
+	})
+	manager := mediator.Inject(container, mediator.WithTransport(client))
+
+	if err := mediator.Publish(manager, context.Background(), UserCreated{}); err != nil || !called {
+		t.Fatalf("expected local handler only, got %v", err)
+	}
+}
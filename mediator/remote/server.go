@@ -0,0 +1,129 @@
+// Package remote exposes mediator requests and events over HTTP
+// and provides a [mediator.Transport] forwarding them to a remote service.
+//
+// Requests and events are sent as JSON encoded [mediator.Envelope] named by [mediator.EventRegistry],
+// so both sides must register the same types.
+package remote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/oesand/octo/mediator"
+)
+
+// Server is an http.Handler exposing requests and events of the manager:
+//   - POST /send accepts an envelope with a request and responds with the JSON encoded response,
+//   - POST /publish accepts an envelope with an event and responds with 204 No Content.
+//
+// Requests must be exposed with [HandleRequest], events must be registered in the registry.
+type Server struct {
+	manager  *mediator.Manager
+	registry *mediator.EventRegistry
+	mux      *http.ServeMux
+
+	mu       sync.RWMutex
+	requests map[reflect.Type]sendFunc
+}
+
+type sendFunc func(r *http.Request, request any) (any, error)
+
+// NewServer creates a server dispatching decoded requests and events to the manager.
+func NewServer(manager *mediator.Manager, registry *mediator.EventRegistry) *Server {
+	server := &Server{
+		manager:  manager,
+		registry: registry,
+		mux:      http.NewServeMux(),
+		requests: make(map[reflect.Type]sendFunc),
+	}
+	server.mux.HandleFunc("POST /send", server.send)
+	server.mux.HandleFunc("POST /publish", server.publish)
+	return server
+}
+
+// HandleRequest exposes requests of type TRequest by the server and registers the type in its registry.
+func HandleRequest[TRequest mediator.Request[TResponse], TResponse any](server *Server) {
+	typ := reflect.TypeFor[TRequest]()
+	server.registry.Register(typ)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	server.requests[typ] = func(r *http.Request, request any) (any, error) {
+		return mediator.Send[TRequest, TResponse](server.manager, r.Context(), request.(TRequest))
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) send(w http.ResponseWriter, r *http.Request) {
+	request, ok := s.decode(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.RLock()
+	send, ok := s.requests[reflect.TypeOf(request)]
+	s.mu.RUnlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("request %T is not exposed", request))
+		return
+	}
+
+	response, err := send(r, request)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (s *Server) publish(w http.ResponseWriter, r *http.Request) {
+	event, ok := s.decode(w, r)
+	if !ok {
+		return
+	}
+
+	if err := mediator.Publish(s.manager, r.Context(), event); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) decode(w http.ResponseWriter, r *http.Request) (any, bool) {
+	var envelope mediator.Envelope
+	if err := json.NewDecoder(r.Body).Decode(&envelope); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	value, err := s.registry.Decode(mediator.JSONCodec, envelope)
+	if errors.Is(err, mediator.ErrUnknownEvent) {
+		writeError(w, http.StatusNotFound, err)
+		return nil, false
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+	return value, true
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorBody{Error: err.Error()})
+}
@@ -2,6 +2,9 @@ package mediator
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
 
 	"github.com/oesand/octo"
 )
@@ -37,12 +40,152 @@ type RequestHandler[TRequest Request[TResponse], TResponse any] interface {
 
 // Send resolves a RequestHandler for the given request/response types from the container
 // and calls its Request method. This is the entry point for executing a request.
+//
+// The call is wrapped by all [PipelineBehavior] registered in the container.
+// When the container has no handler and the manager has a [Transport],
+// the request is sent to the remote service.
+//
+// With [WithScope] the handler is resolved from a scope created for the call.
 func Send[TRequest Request[TResponse], TResponse any](
 	manager *Manager,
 	ctx context.Context,
 	request TRequest,
+	options ...CallOption,
+) (_ TResponse, err error) {
+	table := manager.dispatch()
+	if len(options) > 0 {
+		if opts := manager.callOptions(options); opts.scope != nil {
+			var scope *octo.Container
+			ctx, scope = manager.beginScope(ctx, opts.scope)
+			defer func() {
+				err = endScope(scope, err)
+			}()
+		}
+	}
+
+	container := manager.containerFor(ctx)
+	handler := octo.TryResolve[RequestHandler[TRequest, TResponse]](container)
+	if handler == nil {
+		if manager.transport == nil {
+			handler = octo.Resolve[RequestHandler[TRequest, TResponse]](container)
+		} else {
+			handler = remoteRequestHandler[TRequest, TResponse]{transport: manager.transport}
+		}
+	}
+	return handleRequest(table, ctx, handler, request)
+}
+
+// SendAll resolves every RequestHandler for the given request/response types from the container,
+// calls them with the manager [PublishStrategy] and returns responses in the order of [octo.ResolveAll].
+//
+// The call is wrapped by [PipelineBehavior] registered in the container once for all handlers,
+// behaviors receive the slice of responses as response.
+//
+// With [ParallelWaitAll] and [Sequential] strategies responses of succeeded handlers are returned along with the error,
+// with [ParallelStopOnError] only the error is returned. [FireAndForget] strategy is not supported.
+// With [WithScope] handlers are resolved from a scope created for the call,
+// which is disposed after all of them returned.
+func SendAll[TRequest Request[TResponse], TResponse any](
+	manager *Manager,
+	ctx context.Context,
+	request TRequest,
+	options ...CallOption,
+) (_ []TResponse, err error) {
+	table := manager.dispatch()
+	opts := manager.callOptions(options)
+	if opts.strategy == FireAndForget {
+		return nil, errors.New("mediator: fire-and-forget strategy is not supported by SendAll")
+	}
+	if opts.scope != nil {
+		var scope *octo.Container
+		ctx, scope = manager.beginScope(ctx, opts.scope)
+		defer func() {
+			err = endScope(scope, err)
+		}()
+	}
+
+	handlers := octo.ResolveAll[RequestHandler[TRequest, TResponse]](manager.containerFor(ctx))
+	responses := make([]TResponse, len(handlers))
+	tasks := make([]task, len(handlers))
+	for i, handler := range handlers {
+		tasks[i] = task{
+			info: HandlerInfo{Type: reflect.TypeOf(handler)},
+			run: func(ctx context.Context) error {
+				resp, err := handler.Request(ctx, request)
+				if err != nil {
+					return err
+				}
+				responses[i] = resp
+				return nil
+			},
+		}
+	}
+
+	run := runTasks
+	if opts.scope != nil {
+		run = runTasksInScope
+	}
+	gather := func(ctx context.Context) (any, error) {
+		err := run(ctx, opts.strategy, tasks)
+		if err != nil && opts.strategy == ParallelStopOnError {
+			// Remaining handlers may still write responses
+			return nil, err
+		}
+		return responses, err
+	}
+
+	if len(table.behaviors) == 0 {
+		return castResponse[[]TResponse](gather(ctx))
+	}
+	return castResponse[[]TResponse](table.runPipeline(ctx, request, gather))
+}
+
+func handleRequest[TRequest Request[TResponse], TResponse any](
+	table *dispatchTable,
+	ctx context.Context,
+	handler RequestHandler[TRequest, TResponse],
+	request TRequest,
 ) (TResponse, error) {
-	manager.ensureInit()
-	handler := octo.Resolve[RequestHandler[TRequest, TResponse]](manager.container)
-	return handler.Request(ctx, request)
+	if len(table.behaviors) == 0 {
+		return handler.Request(ctx, request)
+	}
+
+	resp, err := table.runPipeline(ctx, request, func(ctx context.Context) (any, error) {
+		return handler.Request(ctx, request)
+	})
+	return castResponse[TResponse](resp, err)
+}
+
+func castResponse[TResponse any](resp any, err error) (TResponse, error) {
+	var zero TResponse
+	if resp == nil {
+		return zero, err
+	}
+
+	typed, ok := resp.(TResponse)
+	if !ok {
+		return zero, fmt.Errorf("mediator: pipeline returned %T instead of %s", resp, reflect.TypeFor[TResponse]().String())
+	}
+	return typed, err
+}
+
+// RequestHandlerFunc is an adapter to allow the use of ordinary functions as [RequestHandler].
+type RequestHandlerFunc[TRequest Request[TResponse], TResponse any] func(ctx context.Context, request TRequest) (TResponse, error)
+
+func (f RequestHandlerFunc[TRequest, TResponse]) Request(ctx context.Context, request TRequest) (TResponse, error) {
+	return f(ctx, request)
+}
+
+// HandleFunc registers a function as RequestHandler[TRequest, TResponse] in the container.
+//
+// Example:
+//
+//	mediator.HandleFunc(container, func(ctx context.Context, q GetUser) (*User, error) {
+//		return repo.Find(ctx, q.ID)
+//	})
+func HandleFunc[TRequest Request[TResponse], TResponse any](
+	container *octo.Container,
+	handler func(ctx context.Context, request TRequest) (TResponse, error),
+) {
+	octo.InjectValue[RequestHandler[TRequest, TResponse]](container, RequestHandlerFunc[TRequest, TResponse](handler))
 }
@@ -2,6 +2,9 @@ package mediator_test
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -53,3 +56,89 @@ func TestSend_RequestHandler(t *testing.T) {
 		t.Fatal("expected handler2 not called")
 	}
 }
+
+func TestSend_HandleFunc(t *testing.T) {
+	container := octo.New()
+	mediator.HandleFunc(container, func(ctx context.Context, req TestRequest) (TestResponse, error) {
+		return TestResponse{Result: req.Value + 1}, nil
+	})
+	manager := mediator.Inject(container)
+
+	resp, err := mediator.Send(manager, context.Background(), TestRequest{Value: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Result != 4 {
+		t.Fatalf("expected 4, got %d", resp.Result)
+	}
+}
+
+type QuoteRequest struct {
+	mediator.Request[int]
+}
+
+func TestSendAll(t *testing.T) {
+	quoteErr := errors.New("no quote")
+
+	container := octo.New()
+	mediator.HandleFunc(container, func(ctx context.Context, req QuoteRequest) (int, error) {
+		return 10, nil
+	})
+	mediator.HandleFunc(container, func(ctx context.Context, req QuoteRequest) (int, error) {
+		return 0, quoteErr
+	})
+	mediator.HandleFunc(container, func(ctx context.Context, req QuoteRequest) (int, error) {
+		return 30, nil
+	})
+	manager := mediator.Inject(container)
+
+	tests := []struct {
+		strategy mediator.PublishStrategy
+		want     []int
+	}{
+		{strategy: mediator.ParallelWaitAll, want: []int{10, 0, 30}},
+		{strategy: mediator.Sequential, want: []int{10, 0, 0}},
+		{strategy: mediator.ParallelStopOnError, want: nil},
+	}
+
+	for _, tt := range tests {
+		resp, err := mediator.SendAll(manager, context.Background(), QuoteRequest{}, mediator.WithStrategy(tt.strategy))
+		if !errors.Is(err, quoteErr) {
+			t.Fatalf("strategy %d: expected quote error, got %v", tt.strategy, err)
+		}
+		if !slices.Equal(resp, tt.want) {
+			t.Fatalf("strategy %d: expected %v, got %v", tt.strategy, tt.want, resp)
+		}
+	}
+}
+
+func TestSendAll_PipelineOnce(t *testing.T) {
+	var trace []string
+
+	container := octo.New()
+	mediator.HandleFunc(container, func(ctx context.Context, req QuoteRequest) (int, error) {
+		return 10, nil
+	})
+	mediator.HandleFunc(container, func(ctx context.Context, req QuoteRequest) (int, error) {
+		return 20, nil
+	})
+	octo.InjectValue(container, &TraceBehavior{name: "trace", trace: &trace})
+	manager := mediator.Inject(container)
+
+	resp, err := mediator.SendAll(manager, context.Background(), QuoteRequest{})
+	if err != nil || !slices.Equal(resp, []int{10, 20}) {
+		t.Fatalf("unexpected result: %v, %v", resp, err)
+	}
+	if got := strings.Join(trace, " "); got != "trace> <trace" {
+		t.Fatalf("expected pipeline run once, got %s", got)
+	}
+}
+
+func TestSendAll_NoHandlers(t *testing.T) {
+	manager := mediator.Inject(octo.New())
+
+	resp, err := mediator.SendAll(manager, context.Background(), QuoteRequest{})
+	if err != nil || len(resp) != 0 {
+		t.Fatalf("expected empty result, got %v, %v", resp, err)
+	}
+}
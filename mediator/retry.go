@@ -0,0 +1,149 @@
+package mediator
+
+import (
+	"context"
+	"math/rand/v2"
+	"reflect"
+	"time"
+)
+
+// RetryPolicy describes how failed handler calls are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of handler calls, values below 1 mean a single call.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff limits the delay between retries, zero means no limit.
+	MaxBackoff time.Duration
+
+	// Multiplier increases the delay after every retry, values below 1 mean 2.
+	Multiplier float64
+
+	// Jitter is the fraction of the delay, from 0 to 1, randomly subtracted from it.
+	Jitter float64
+
+	// Retryable reports whether the error should be retried, nil means every error is retryable.
+	Retryable func(err error) bool
+}
+
+// Backoff returns the delay before the retry following the given failed attempt, starting from 1.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		backoff *= multiplier
+		if p.MaxBackoff > 0 && backoff >= float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 {
+		backoff = min(backoff, float64(p.MaxBackoff))
+	}
+	if p.Jitter > 0 {
+		backoff -= backoff * min(p.Jitter, 1) * rand.Float64()
+	}
+	return time.Duration(backoff)
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// RetryOption represents a function that modifies [Retry] behavior configuration.
+type RetryOption func(*retryBehavior)
+
+// WithHandlerRetry overrides the retry policy for handlers of type THandler.
+func WithHandlerRetry[THandler any](policy RetryPolicy) RetryOption {
+	return func(b *retryBehavior) {
+		if b.handlers == nil {
+			b.handlers = make(map[reflect.Type]RetryPolicy)
+		}
+		b.handlers[reflect.TypeFor[THandler]()] = policy
+	}
+}
+
+// WithHandlerIDRetry overrides the retry policy for the handler with the [HandlerInfo.ID],
+// it takes precedence over [WithHandlerRetry] and distinguishes handlers of the same type.
+func WithHandlerIDRetry(id string, policy RetryPolicy) RetryOption {
+	return func(b *retryBehavior) {
+		if b.ids == nil {
+			b.ids = make(map[string]RetryPolicy)
+		}
+		b.ids[id] = policy
+	}
+}
+
+// WithDeadLetters sets the sink receiving events whose handlers failed all attempts.
+// Errors of dead-lettered events are not returned to the caller.
+func WithDeadLetters(sink DeadLetterSink) RetryOption {
+	return func(b *retryBehavior) {
+		b.sink = sink
+	}
+}
+
+type retryBehavior struct {
+	policy   RetryPolicy
+	handlers map[reflect.Type]RetryPolicy
+	ids      map[string]RetryPolicy
+	sink     DeadLetterSink
+}
+
+// Retry returns an [EventBehavior] which retries failed handler calls by the policy.
+// Policy can be overridden per handler type with [WithHandlerRetry].
+//
+// When all attempts failed or the error is not retryable, the event is sent
+// to the sink set by [WithDeadLetters] if any, otherwise the last error is returned.
+func Retry(policy RetryPolicy, options ...RetryOption) EventBehavior {
+	behavior := &retryBehavior{policy: policy}
+	for _, option := range options {
+		option(behavior)
+	}
+	return behavior
+}
+
+func (b *retryBehavior) HandleEvent(ctx context.Context, handler HandlerInfo, event any, next func(ctx context.Context) error) error {
+	policy, ok := b.ids[handler.ID]
+	if !ok {
+		policy, ok = b.handlers[handler.Type]
+	}
+	if !ok {
+		policy = b.policy
+	}
+
+	var err error
+	var attempts int
+	for {
+		attempts++
+		if err = next(ctx); err == nil {
+			return nil
+		}
+		if attempts >= policy.MaxAttempts || !policy.retryable(err) {
+			break
+		}
+
+		timer := time.NewTimer(policy.Backoff(attempts))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return context.Cause(ctx)
+		case <-timer.C:
+		}
+	}
+
+	if b.sink == nil {
+		return err
+	}
+	return b.sink.Put(ctx, DeadLetter{
+		Event:    event,
+		Handler:  handler,
+		Err:      err,
+		Attempts: attempts,
+		At:       time.Now(),
+	})
+}
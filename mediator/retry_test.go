@@ -0,0 +1,188 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+)
+
+type FlakyHandler struct {
+	Failures int32
+	Calls    atomic.Int32
+}
+
+func (h *FlakyHandler) Notification(ctx context.Context, e EventX) error {
+	if h.Calls.Add(1) <= h.Failures {
+		return errHandler
+	}
+	return nil
+}
+
+func TestRetry_RetriesUntilSuccess(t *testing.T) {
+	container := octo.New()
+	h := &FlakyHandler{Failures: 2}
+	octo.InjectValue(container, h)
+	octo.InjectValue(container, mediator.Retry(mediator.RetryPolicy{MaxAttempts: 3}))
+	manager := mediator.Inject(container)
+
+	if err := mediator.Publish(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := h.Calls.Load(); n != 3 {
+		t.Fatalf("expected 3 calls, got %d", n)
+	}
+}
+
+func TestRetry_NotRetryable(t *testing.T) {
+	container := octo.New()
+	h := &FlakyHandler{Failures: 2}
+	octo.InjectValue(container, h)
+	octo.InjectValue(container, mediator.Retry(mediator.RetryPolicy{
+		MaxAttempts: 3,
+		Retryable:   func(err error) bool { return !errors.Is(err, errHandler) },
+	}))
+	manager := mediator.Inject(container)
+
+	if err := mediator.Publish(manager, context.Background(), EventX{}); !errors.Is(err, errHandler) {
+		t.Fatalf("expected handler error, got %v", err)
+	}
+	if n := h.Calls.Load(); n != 1 {
+		t.Fatalf("expected 1 call, got %d", n)
+	}
+}
+
+func TestRetry_HandlerOverride(t *testing.T) {
+	container := octo.New()
+	h := &FlakyHandler{Failures: 2}
+	octo.InjectValue(container, h)
+	octo.InjectValue(container, mediator.Retry(
+		mediator.RetryPolicy{MaxAttempts: 1},
+		mediator.WithHandlerRetry[*FlakyHandler](mediator.RetryPolicy{MaxAttempts: 3}),
+	))
+	manager := mediator.Inject(container)
+
+	if err := mediator.Publish(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRetry_DeadLetterAndReplay(t *testing.T) {
+	container := octo.New()
+	h := &FlakyHandler{Failures: 2}
+	octo.InjectValue(container, h)
+	letters := &mediator.MemoryDeadLetters{}
+	octo.InjectValue(container, mediator.Retry(
+		mediator.RetryPolicy{MaxAttempts: 2},
+		mediator.WithDeadLetters(letters),
+	))
+	manager := mediator.Inject(container)
+	ctx := context.Background()
+
+	if err := mediator.Publish(manager, ctx, EventX{Name: "x"}); err != nil {
+		t.Fatalf("expected dead-lettered error not returned, got %v", err)
+	}
+
+	dead := letters.Letters()
+	if len(dead) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(dead))
+	}
+	letter := dead[0]
+	if letter.Attempts != 2 || !errors.Is(letter.Err, errHandler) ||
+		letter.Handler.Type.String() != "*mediator_test.FlakyHandler" || letter.Event.(EventX).Name != "x" {
+		t.Fatalf("unexpected dead letter: %+v", letter)
+	}
+
+	if err := letters.Replay(manager, ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := h.Calls.Load(); n != 3 {
+		t.Fatalf("expected 3 calls, got %d", n)
+	}
+	if n := len(letters.Letters()); n != 0 {
+		t.Fatalf("expected no dead letters after replay, got %d", n)
+	}
+}
+
+func TestReplay_HandlerNotFound(t *testing.T) {
+	manager := mediator.Inject(octo.New())
+	letter := mediator.DeadLetter{Event: EventX{}, Handler: mediator.HandlerInfo{Name: "missing"}}
+	if err := mediator.Replay(manager, context.Background(), letter); !errors.Is(err, mediator.ErrHandlerNotFound) {
+		t.Fatalf("expected handler not found, got %v", err)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := mediator.RetryPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 30 * time.Millisecond}
+	expected := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond, 30 * time.Millisecond}
+	for i, want := range expected {
+		if got := policy.Backoff(i + 1); got != want {
+			t.Fatalf("attempt %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for range 10 {
+		if got := policy.Backoff(1); got < 5*time.Millisecond || got > 10*time.Millisecond {
+			t.Fatalf("expected jittered backoff in range, got %v", got)
+		}
+	}
+}
+
+func TestReplay_SameTypeHandlers(t *testing.T) {
+	container := octo.New()
+	var first, second atomic.Int32
+	mediator.HandleEventFunc(container, func(ctx context.Context, e EventX) error {
+		first.Add(1)
+		return nil
+	})
+	mediator.HandleEventFunc(container, func(ctx context.Context, e EventX) error {
+		if second.Add(1) == 1 {
+			return errHandler
+		}
+		return nil
+	})
+	letters := &mediator.MemoryDeadLetters{}
+	octo.InjectValue(container, mediator.Retry(mediator.RetryPolicy{}, mediator.WithDeadLetters(letters)))
+	manager := mediator.Inject(container, mediator.WithPublishStrategy(mediator.Sequential))
+	ctx := context.Background()
+
+	if err := mediator.Publish(manager, ctx, EventX{}); err != nil {
+		t.Fatalf("expected dead-lettered error not returned, got %v", err)
+	}
+	dead := letters.Letters()
+	if len(dead) != 1 || !strings.HasSuffix(dead[0].Handler.ID, "#1") {
+		t.Fatalf("expected dead letter of second handler, got %+v", dead)
+	}
+
+	if err := letters.Replay(manager, ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Load() != 1 || second.Load() != 2 {
+		t.Fatalf("expected replay to failed handler only, got %d and %d", first.Load(), second.Load())
+	}
+	if n := len(letters.Letters()); n != 0 {
+		t.Fatalf("expected no dead letters after replay, got %d", n)
+	}
+}
+
+func TestRetry_HandlerIDOverride(t *testing.T) {
+	container := octo.New()
+	h := &FlakyHandler{Failures: 2}
+	octo.InjectNamedValue(container, "flaky", h)
+	octo.InjectValue(container, mediator.Retry(
+		mediator.RetryPolicy{MaxAttempts: 1},
+		mediator.WithHandlerRetry[*FlakyHandler](mediator.RetryPolicy{MaxAttempts: 2}),
+		mediator.WithHandlerIDRetry("flaky(*mediator_test.FlakyHandler)", mediator.RetryPolicy{MaxAttempts: 3}),
+	))
+	manager := mediator.Inject(container)
+
+	if err := mediator.Publish(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
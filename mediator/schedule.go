@@ -0,0 +1,412 @@
+package mediator
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrNotScheduled is returned by [CancelScheduled] when no event is scheduled with the id.
+var ErrNotScheduled = errors.New("mediator: event is not scheduled")
+
+// Clock provides the current time and timers to the scheduler,
+// it can be replaced in tests with [WithClock].
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// WaitUntil returns a channel receiving the time when it reaches at.
+	WaitUntil(at time.Time) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) WaitUntil(at time.Time) <-chan time.Time {
+	return time.After(time.Until(at))
+}
+
+// ScheduledEvent is an event waiting to be published at the time.
+type ScheduledEvent struct {
+	ID    string
+	Event any
+	At    time.Time
+}
+
+// ScheduleStore persists scheduled events, so they are published after restart.
+type ScheduleStore interface {
+	// Add stores the scheduled event.
+	Add(ctx context.Context, event ScheduledEvent) error
+
+	// Remove removes the event published or canceled.
+	Remove(ctx context.Context, id string) error
+
+	// All returns all stored events.
+	All(ctx context.Context) ([]ScheduledEvent, error)
+}
+
+// WithClock sets the clock used by scheduled publishing.
+func WithClock(clock Clock) Option {
+	return func(m *Manager) {
+		m.clock = clock
+	}
+}
+
+// WithScheduleStore sets the store of events scheduled by [PublishAt] and [PublishAfter].
+func WithScheduleStore(store ScheduleStore) Option {
+	return func(m *Manager) {
+		m.scheduleStore = store
+	}
+}
+
+// PublishAt schedules the event to be published at the time and returns its id.
+// Events scheduled in the past are published immediately.
+//
+// Handlers receive the context values but not its cancellation,
+// events restored from the store after restart receive an empty context.
+// Errors of handlers are reported to the [WithAsyncErrorHandler] handler.
+func PublishAt(manager *Manager, ctx context.Context, event any, at time.Time) (string, error) {
+	s, err := manager.scheduler(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	scheduled := ScheduledEvent{ID: newMessageID(), Event: event, At: at}
+	if err = s.store.Add(ctx, scheduled); err != nil {
+		return "", err
+	}
+	if err = s.push(context.WithoutCancel(ctx), scheduled); err != nil {
+		_ = s.store.Remove(ctx, scheduled.ID)
+		return "", err
+	}
+	return scheduled.ID, nil
+}
+
+// PublishAfter schedules the event to be published after the delay and returns its id.
+func PublishAfter(manager *Manager, ctx context.Context, event any, delay time.Duration) (string, error) {
+	manager.ensureInit()
+	return PublishAt(manager, ctx, event, manager.clockOrDefault().Now().Add(delay))
+}
+
+// CancelScheduled cancels the scheduled event which is not published yet.
+func CancelScheduled(manager *Manager, ctx context.Context, id string) error {
+	s, err := manager.scheduler(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	item, ok := s.items[id]
+	if ok {
+		heap.Remove(&s.queue, item.index)
+		delete(s.items, id)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		return ErrNotScheduled
+	}
+	return s.store.Remove(ctx, id)
+}
+
+// StartScheduler loads events stored by [WithScheduleStore] and starts publishing them.
+// Scheduler is started by the first scheduling call as well, so the method
+// is needed only to publish events stored before restart.
+func (m *Manager) StartScheduler(ctx context.Context) error {
+	_, err := m.scheduler(ctx)
+	return err
+}
+
+func (m *Manager) clockOrDefault() Clock {
+	if m.clock == nil {
+		return systemClock{}
+	}
+	return m.clock
+}
+
+func (m *Manager) scheduler(ctx context.Context) (*scheduler, error) {
+	m.ensureInit()
+
+	m.scheduleMu.Lock()
+	defer m.scheduleMu.Unlock()
+
+	if m.schedule != nil {
+		return m.schedule, nil
+	}
+	if m.scheduleDone {
+		return nil, ErrManagerClosed
+	}
+
+	store := m.scheduleStore
+	if store == nil {
+		store = &MemoryScheduleStore{}
+	}
+	events, err := store.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &scheduler{
+		manager: m,
+		clock:   m.clockOrDefault(),
+		store:   store,
+		items:   make(map[string]*scheduledItem),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+	}
+	for _, event := range events {
+		s.add(context.Background(), event)
+	}
+	s.workers.Go(s.run)
+
+	m.schedule = s
+	return s, nil
+}
+
+type scheduledItem struct {
+	ScheduledEvent
+	ctx   context.Context
+	seq   int
+	index int
+}
+
+type scheduleQueue []*scheduledItem
+
+func (q scheduleQueue) Len() int { return len(q) }
+
+func (q scheduleQueue) Less(i, j int) bool {
+	if q[i].At.Equal(q[j].At) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].At.Before(q[j].At)
+}
+
+func (q scheduleQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *scheduleQueue) Push(x any) {
+	item := x.(*scheduledItem)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *scheduleQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return item
+}
+
+type scheduler struct {
+	manager *Manager
+	clock   Clock
+	store   ScheduleStore
+	wake    chan struct{}
+	stop    chan struct{}
+	workers sync.WaitGroup
+
+	mu     sync.Mutex
+	queue  scheduleQueue
+	items  map[string]*scheduledItem
+	seq    int
+	closed bool
+}
+
+func (s *scheduler) add(ctx context.Context, event ScheduledEvent) {
+	s.seq++
+	item := &scheduledItem{ScheduledEvent: event, ctx: ctx, seq: s.seq}
+	heap.Push(&s.queue, item)
+	s.items[event.ID] = item
+}
+
+func (s *scheduler) push(ctx context.Context, event ScheduledEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrManagerClosed
+	}
+	s.add(ctx, event)
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (s *scheduler) run() {
+	// Timer is kept while the earliest event is the same,
+	// so wakes by events scheduled later don't start a new one.
+	var timer <-chan time.Time
+	var deadline time.Time
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			timer = nil
+		} else if next := s.queue[0]; next.At.After(s.clock.Now()) {
+			if timer == nil || !next.At.Equal(deadline) {
+				timer, deadline = s.clock.WaitUntil(next.At), next.At
+			}
+		} else {
+			heap.Pop(&s.queue)
+			delete(s.items, next.ID)
+			s.workers.Go(func() {
+				s.publish(next)
+			})
+			s.mu.Unlock()
+			continue
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-timer:
+			timer = nil
+		case <-s.wake:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *scheduler) publish(item *scheduledItem) {
+	event := asyncEvent{ctx: item.ctx, event: item.Event}
+	if err := Publish(s.manager, item.ctx, item.Event); err != nil {
+		s.manager.reportAsyncError(event, err)
+	}
+	if err := s.store.Remove(item.ctx, item.ID); err != nil {
+		s.manager.reportAsyncError(event, err)
+	}
+}
+
+func (m *Manager) shutdownScheduler(ctx context.Context) error {
+	m.scheduleMu.Lock()
+	m.scheduleDone = true
+	s := m.schedule
+	m.scheduleMu.Unlock()
+
+	if s == nil {
+		return nil
+	}
+	return s.shutdown(ctx)
+}
+
+// shutdown stops the scheduler and waits until started publications are finished.
+// Events not published yet are kept in the store.
+func (s *scheduler) shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.stop)
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+var _ ScheduleStore = &MemoryScheduleStore{}
+
+// MemoryScheduleStore is an in-memory implementation of ScheduleStore,
+// events stored in it are lost on restart.
+type MemoryScheduleStore struct {
+	mu     sync.Mutex
+	events []ScheduledEvent
+}
+
+func (s *MemoryScheduleStore) Add(_ context.Context, event ScheduledEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *MemoryScheduleStore) Remove(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = slices.DeleteFunc(s.events, func(event ScheduledEvent) bool {
+		return event.ID == id
+	})
+	return nil
+}
+
+func (s *MemoryScheduleStore) All(_ context.Context) ([]ScheduledEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.events), nil
+}
+
+var _ ScheduleStore = &FileScheduleStore{}
+
+// FileScheduleStore is a ScheduleStore keeping events in a local file the same way as [FileOutboxStore],
+// concrete event types must be registered with [encoding/gob.Register] as well.
+type FileScheduleStore struct {
+	path string
+
+	mu     sync.Mutex
+	events []ScheduledEvent
+}
+
+// NewFileScheduleStore opens the store at path, loading events saved by previous runs.
+func NewFileScheduleStore(path string) (*FileScheduleStore, error) {
+	events, err := loadGobFile[ScheduledEvent](path)
+	if err != nil {
+		return nil, err
+	}
+	return &FileScheduleStore{path: path, events: events}, nil
+}
+
+func (s *FileScheduleStore) Add(_ context.Context, event ScheduledEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.write(append(slices.Clip(s.events), event))
+}
+
+func (s *FileScheduleStore) Remove(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.write(slices.DeleteFunc(slices.Clone(s.events), func(event ScheduledEvent) bool {
+		return event.ID == id
+	}))
+}
+
+func (s *FileScheduleStore) All(_ context.Context) ([]ScheduledEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.events), nil
+}
+
+// write replaces the file content and the events only if the file was written.
+func (s *FileScheduleStore) write(events []ScheduledEvent) error {
+	if err := writeGobFile(s.path, events); err != nil {
+		return err
+	}
+	s.events = events
+	return nil
+}
@@ -0,0 +1,187 @@
+package mediator_test
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+	"github.com/oesand/octo/mediator/mediatortest"
+)
+
+type ReminderDue struct {
+	Name string
+}
+
+func init() {
+	gob.Register(ReminderDue{})
+}
+
+func TestPublishAfter(t *testing.T) {
+	container := octo.New()
+	recorder := mediatortest.NewRecorder(container)
+	clock := mediatortest.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	manager := mediator.Inject(container, mediator.WithClock(clock))
+	ctx := context.Background()
+
+	if _, err := mediator.PublishAfter(manager, ctx, ReminderDue{Name: "late"}, 2*time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := mediator.PublishAfter(manager, ctx, ReminderDue{Name: "early"}, time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mediatortest.AssertNotPublished[ReminderDue](t, recorder)
+
+	clock.Advance(time.Hour)
+	if e := mediatortest.WaitFor[ReminderDue](t, recorder, time.Second); e.Name != "early" {
+		t.Fatalf("expected early reminder first, got %+v", e)
+	}
+
+	recorder.Reset()
+	clock.Advance(time.Hour)
+	if e := mediatortest.WaitFor[ReminderDue](t, recorder, time.Second); e.Name != "late" {
+		t.Fatalf("expected late reminder, got %+v", e)
+	}
+}
+
+func TestCancelScheduled(t *testing.T) {
+	container := octo.New()
+	recorder := mediatortest.NewRecorder(container)
+	clock := mediatortest.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := &mediator.MemoryScheduleStore{}
+	manager := mediator.Inject(container, mediator.WithClock(clock), mediator.WithScheduleStore(store))
+	ctx := context.Background()
+
+	id, err := mediator.PublishAfter(manager, ctx, ReminderDue{}, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = mediator.CancelScheduled(manager, ctx, id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = mediator.CancelScheduled(manager, ctx, id); !errors.Is(err, mediator.ErrNotScheduled) {
+		t.Fatalf("expected not scheduled error, got %v", err)
+	}
+
+	clock.Advance(time.Minute)
+	if err = manager.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mediatortest.AssertNotPublished[ReminderDue](t, recorder)
+	if events, _ := store.All(ctx); len(events) != 0 {
+		t.Fatalf("expected canceled event removed from store, got %d", len(events))
+	}
+}
+
+func TestScheduler_Restore(t *testing.T) {
+	store := &mediator.MemoryScheduleStore{}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	manager := mediator.Inject(octo.New(), mediator.WithClock(mediatortest.NewFakeClock(start)), mediator.WithScheduleStore(store))
+	if _, err := mediator.PublishAfter(manager, ctx, ReminderDue{Name: "restored"}, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := manager.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := mediator.PublishAfter(manager, ctx, ReminderDue{}, time.Minute); !errors.Is(err, mediator.ErrManagerClosed) {
+		t.Fatalf("expected closed error, got %v", err)
+	}
+	if events, _ := store.All(ctx); len(events) != 1 {
+		t.Fatalf("expected pending event kept in store, got %d", len(events))
+	}
+
+	container := octo.New()
+	recorder := mediatortest.NewRecorder(container)
+	clock := mediatortest.NewFakeClock(start)
+	restarted := mediator.Inject(container, mediator.WithClock(clock), mediator.WithScheduleStore(store))
+	if err := restarted.StartScheduler(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clock.Advance(time.Minute)
+	if e := mediatortest.WaitFor[ReminderDue](t, recorder, time.Second); e.Name != "restored" {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+
+	if err := restarted.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if events, _ := store.All(ctx); len(events) != 0 {
+		t.Fatalf("expected published event removed from store, got %d", len(events))
+	}
+}
+
+func TestScheduler_KeepsTimer(t *testing.T) {
+	clock := mediatortest.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	manager := mediator.Inject(octo.New(), mediator.WithClock(clock))
+	ctx := context.Background()
+
+	if _, err := mediator.PublishAfter(manager, ctx, ReminderDue{}, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for range 5 {
+		if _, err := mediator.PublishAfter(manager, ctx, ReminderDue{}, time.Hour); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if n := clock.Waiters(); n != 1 {
+		t.Fatalf("expected single timer for the earliest event, got %d", n)
+	}
+	if err := manager.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestFileScheduleStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule")
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	store, err := mediator.NewFileScheduleStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	manager := mediator.Inject(octo.New(), mediator.WithClock(mediatortest.NewFakeClock(start)), mediator.WithScheduleStore(store))
+	if _, err = mediator.PublishAfter(manager, ctx, ReminderDue{Name: "kept"}, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	id, err := mediator.PublishAfter(manager, ctx, ReminderDue{Name: "canceled"}, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = mediator.CancelScheduled(manager, ctx, id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = manager.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reopened, err := mediator.NewFileScheduleStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	container := octo.New()
+	recorder := mediatortest.NewRecorder(container)
+	clock := mediatortest.NewFakeClock(start)
+	restarted := mediator.Inject(container, mediator.WithClock(clock), mediator.WithScheduleStore(reopened))
+	if err = restarted.StartScheduler(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clock.Advance(time.Minute)
+	if e := mediatortest.WaitFor[ReminderDue](t, recorder, time.Second); e.Name != "kept" {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+	if err = restarted.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if events, _ := reopened.All(ctx); len(events) != 0 {
+		t.Fatalf("expected published event removed from store, got %d", len(events))
+	}
+}
@@ -0,0 +1,79 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/internal"
+)
+
+// scopeCtxKey is boxed once, so looking up the scope on every publish doesn't allocate.
+var scopeCtxKey any = internal.CtxKey{Key: "mediator/scope"}
+
+// WithScope makes [Send], [SendAll] or [Publish] create a child scope of the container for the call,
+// see [octo.NewScope]. Setup injects call specific values into the scope,
+// like request metadata, a transaction or the caller identity.
+//
+// Handlers and their scoped dependencies are resolved from the scope,
+// which is disposed when the call and its handlers returned. Nested calls made with the context
+// use the same scope, or create a child of it with WithScope.
+// [FireAndForget] strategy is not supported with the scope.
+func WithScope(setup func(scope *octo.Container)) CallOption {
+	return func(o *callOptions) {
+		o.scope = setup
+	}
+}
+
+// Scope returns the scope of the current call or nil if the call has no scope.
+func Scope(ctx context.Context) *octo.Container {
+	scope, _ := ctx.Value(scopeCtxKey).(*octo.Container)
+	return scope
+}
+
+// containerFor returns the scope of the context or the manager container.
+func (m *Manager) containerFor(ctx context.Context) *octo.Container {
+	if scope := Scope(ctx); scope != nil {
+		return scope
+	}
+	return m.container
+}
+
+// withoutScope detaches the context from the scope of the call.
+func withoutScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopeCtxKey, (*octo.Container)(nil))
+}
+
+// unscopedHandlers returns the handlers without scoped ones, which are called only within a scope.
+func unscopedHandlers(handlers []eventHandler) []eventHandler {
+	isScoped := func(handler eventHandler) bool {
+		return handler.scoped
+	}
+	if !slices.ContainsFunc(handlers, isScoped) {
+		return handlers
+	}
+	return slices.DeleteFunc(slices.Clone(handlers), isScoped)
+}
+
+func (m *Manager) beginScope(ctx context.Context, setup func(scope *octo.Container)) (context.Context, *octo.Container) {
+	scope := octo.NewScope(m.containerFor(ctx))
+	setup(scope)
+	return context.WithValue(ctx, scopeCtxKey, scope), scope
+}
+
+// endScope disposes the scope and joins its error with the call error.
+func endScope(scope *octo.Container, err error) error {
+	if disposeErr := octo.DisposeScope(scope); disposeErr != nil {
+		return errors.Join(err, disposeErr)
+	}
+	return err
+}
+
+// declarationValue returns the value of the handler declaration from the scope of the context.
+func declarationValue(ctx context.Context, decl octo.Declaration) any {
+	if scope := Scope(ctx); scope != nil {
+		return octo.ResolveDeclaration(scope, decl)
+	}
+	return decl.Value()
+}
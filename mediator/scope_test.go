@@ -0,0 +1,245 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+)
+
+type UnitOfWork struct {
+	User   string
+	Closed bool
+}
+
+func (u *UnitOfWork) Close() error {
+	u.Closed = true
+	return nil
+}
+
+type WhoAmI struct {
+	mediator.Request[*UnitOfWork]
+}
+
+type WhoAmIHandler struct {
+	Work *UnitOfWork
+}
+
+func (h *WhoAmIHandler) Request(ctx context.Context, req WhoAmI) (*UnitOfWork, error) {
+	return h.Work, nil
+}
+
+type AuditHandler struct {
+	Work    *UnitOfWork
+	Manager *mediator.Manager
+	Nested  *UnitOfWork
+}
+
+func (h *AuditHandler) Notification(ctx context.Context, e EventX) error {
+	nested, err := mediator.Send(h.Manager, ctx, WhoAmI{})
+	h.Nested = nested
+	return err
+}
+
+func withUser(user string) mediator.CallOption {
+	return mediator.WithScope(func(scope *octo.Container) {
+		octo.InjectNamedValue(scope, "user", user)
+	})
+}
+
+func TestSend_WithScope(t *testing.T) {
+	container := octo.New()
+	octo.InjectScoped(container, func(c *octo.Container) *UnitOfWork {
+		return &UnitOfWork{User: octo.ResolveNamed[string](c, "user")}
+	})
+	octo.InjectScoped(container, func(c *octo.Container) *WhoAmIHandler {
+		return &WhoAmIHandler{Work: octo.Resolve[*UnitOfWork](c)}
+	})
+	manager := mediator.Inject(container)
+	ctx := context.Background()
+
+	alice, err := mediator.Send(manager, ctx, WhoAmI{}, withUser("alice"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bob, _ := mediator.Send(manager, ctx, WhoAmI{}, withUser("bob"))
+
+	if alice.User != "alice" || bob.User != "bob" {
+		t.Fatalf("expected handler resolved per call, got %s and %s", alice.User, bob.User)
+	}
+	if !alice.Closed || !bob.Closed {
+		t.Fatal("expected scoped instances disposed after call")
+	}
+}
+
+func TestPublish_WithScope(t *testing.T) {
+	container := octo.New()
+	octo.InjectScoped(container, func(c *octo.Container) *UnitOfWork {
+		return &UnitOfWork{User: octo.ResolveNamed[string](c, "user")}
+	})
+	octo.InjectScoped(container, func(c *octo.Container) *WhoAmIHandler {
+		return &WhoAmIHandler{Work: octo.Resolve[*UnitOfWork](c)}
+	})
+	var handlers []*AuditHandler
+	octo.InjectScoped(container, func(c *octo.Container) *AuditHandler {
+		h := &AuditHandler{Work: octo.Resolve[*UnitOfWork](c), Manager: octo.Resolve[*mediator.Manager](c)}
+		handlers = append(handlers, h)
+		return h
+	})
+	manager := mediator.Inject(container)
+
+	if err := mediator.Publish(manager, context.Background(), EventX{}, withUser("alice")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(handlers) != 1 {
+		t.Fatalf("expected handler created for the call, got %d", len(handlers))
+	}
+	h := handlers[0]
+	if h.Work.User != "alice" || h.Nested != h.Work {
+		t.Fatal("expected nested call to share the scope")
+	}
+	if !h.Work.Closed {
+		t.Fatal("expected scoped instances disposed after call")
+	}
+}
+
+func TestSendAll_WithScope(t *testing.T) {
+	container := octo.New()
+	octo.InjectScoped(container, func(c *octo.Container) *UnitOfWork {
+		return &UnitOfWork{User: octo.ResolveNamed[string](c, "user")}
+	})
+	octo.InjectScoped(container, func(c *octo.Container) *WhoAmIHandler {
+		return &WhoAmIHandler{Work: octo.Resolve[*UnitOfWork](c)}
+	})
+	manager := mediator.Inject(container)
+	ctx := context.Background()
+
+	responses, err := mediator.SendAll(manager, ctx, WhoAmI{}, withUser("alice"))
+	if err != nil || len(responses) != 1 || responses[0].User != "alice" || !responses[0].Closed {
+		t.Fatalf("expected scoped handler called and disposed, got %+v %v", responses, err)
+	}
+
+	if responses, err = mediator.SendAll(manager, ctx, WhoAmI{}); err != nil || len(responses) != 0 {
+		t.Fatalf("expected scoped handler skipped without scope, got %+v %v", responses, err)
+	}
+}
+
+func TestPublish_WithScopeStopOnError(t *testing.T) {
+	container := octo.New()
+	octo.InjectScoped(container, func(c *octo.Container) *UnitOfWork {
+		return &UnitOfWork{User: octo.ResolveNamed[string](c, "user")}
+	})
+	started, done := make(chan struct{}), make(chan struct{})
+	var closed bool
+	mediator.HandleEventFunc(container, func(ctx context.Context, e EventX) error {
+		work := octo.Resolve[*UnitOfWork](mediator.Scope(ctx))
+		close(started)
+		time.Sleep(20 * time.Millisecond)
+		closed = work.Closed
+		close(done)
+		return nil
+	})
+	mediator.HandleEventFunc(container, func(ctx context.Context, e EventX) error {
+		<-started
+		return errHandler
+	})
+	manager := mediator.Inject(container)
+
+	err := mediator.Publish(manager, context.Background(), EventX{},
+		withUser("alice"), mediator.WithStrategy(mediator.ParallelStopOnError))
+	if !errors.Is(err, errHandler) {
+		t.Fatalf("expected handler error, got %v", err)
+	}
+	<-done
+	if closed {
+		t.Fatal("expected scope disposed after running handlers returned")
+	}
+}
+
+type ScopedBehavior struct{}
+
+func (ScopedBehavior) HandleEvent(ctx context.Context, handler mediator.HandlerInfo, event any, next func(ctx context.Context) error) error {
+	return next(ctx)
+}
+
+func TestInject_ScopedBehaviorsSkipped(t *testing.T) {
+	container := octo.New()
+	h := &EventHandlerX{}
+	octo.InjectValue(container, h)
+	octo.InjectScoped(container, func(c *octo.Container) ScopedBehavior {
+		return ScopedBehavior{}
+	})
+	octo.InjectScoped(container, func(c *octo.Container) *AllEventsHandler {
+		return &AllEventsHandler{}
+	})
+	manager := mediator.Inject(container)
+
+	if err := mediator.Publish(manager, context.Background(), EventX{}); err != nil || !h.Called.Load() {
+		t.Fatalf("expected local handler called, got %v", err)
+	}
+	if err := mediator.Validate(manager); err == nil || !strings.Contains(err.Error(), "AllEventsHandler is scoped") {
+		t.Fatalf("expected scoped mass handler reported, got %v", err)
+	}
+}
+
+func TestPublish_ScopedHandlerWithoutScope(t *testing.T) {
+	container := octo.New()
+	var created int
+	octo.InjectScoped(container, func(c *octo.Container) *AuditHandler {
+		created++
+		return &AuditHandler{}
+	})
+	h := &EventHandlerX{}
+	octo.InjectValue(container, h)
+	manager := mediator.Inject(container)
+
+	if err := mediator.Publish(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !h.Called.Load() || created != 0 {
+		t.Fatalf("expected only unscoped handler called, scoped created %d times", created)
+	}
+}
+
+func TestPublish_WithScopeFireAndForget(t *testing.T) {
+	container := octo.New()
+	var created int
+	octo.InjectScoped(container, func(c *octo.Container) *AuditHandler {
+		created++
+		return &AuditHandler{}
+	})
+	manager := mediator.Inject(container)
+
+	err := mediator.Publish(manager, context.Background(), EventX{},
+		withUser("alice"), mediator.WithStrategy(mediator.FireAndForget))
+	if err == nil || created != 0 {
+		t.Fatalf("expected fire-and-forget rejected with scope, got %v", err)
+	}
+}
+
+func TestStream_WithinScope(t *testing.T) {
+	container := octo.New()
+	octo.InjectScoped(container, func(c *octo.Container) *CountHandler {
+		return &CountHandler{}
+	})
+	var sum int
+	mediator.HandleEventFunc(container, func(ctx context.Context, e EventX) error {
+		for item, err := range mediator.Stream(octo.Resolve[*mediator.Manager](container), ctx, CountRequest{To: 3}) {
+			if err != nil {
+				return err
+			}
+			sum += item
+		}
+		return nil
+	})
+	manager := mediator.Inject(container)
+
+	if err := mediator.Publish(manager, context.Background(), EventX{}, withUser("alice")); err != nil || sum != 6 {
+		t.Fatalf("expected stream handler resolved from scope, got %d %v", sum, err)
+	}
+}
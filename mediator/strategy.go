@@ -0,0 +1,192 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/oesand/octo"
+)
+
+// PublishStrategy defines how [Publish] executes matching handlers.
+type PublishStrategy int
+
+const (
+	// ParallelStopOnError runs handlers concurrently and returns the first error,
+	// cancelling the context of the remaining handlers. This is the default strategy.
+	ParallelStopOnError PublishStrategy = iota
+
+	// ParallelWaitAll runs handlers concurrently, waits for all of them
+	// and returns errors of all failed handlers joined.
+	ParallelWaitAll
+
+	// Sequential runs handlers one by one and stops on the first error.
+	Sequential
+
+	// FireAndForget starts handlers in background and returns immediately.
+	// Handler errors are ignored, context cancellation is not propagated to handlers.
+	FireAndForget
+)
+
+// HandlerError describes an error returned by a specific handler.
+type HandlerError struct {
+	Handler HandlerInfo
+	Err     error
+}
+
+func (e *HandlerError) Error() string {
+	return "mediator: handler " + e.Handler.String() + ": " + e.Err.Error()
+}
+
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// Option represents a function that modifies Manager configuration.
+type Option func(*Manager)
+
+// WithPublishStrategy sets the default strategy used by [Publish].
+func WithPublishStrategy(strategy PublishStrategy) Option {
+	return func(m *Manager) {
+		m.strategy = strategy
+	}
+}
+
+// CallOption represents a function that modifies a single mediator call.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	strategy PublishStrategy
+	scope    func(scope *octo.Container)
+}
+
+// WithStrategy overrides the manager publish strategy for a single call.
+func WithStrategy(strategy PublishStrategy) CallOption {
+	return func(o *callOptions) {
+		o.strategy = strategy
+	}
+}
+
+func (m *Manager) callOptions(options []CallOption) callOptions {
+	opts := callOptions{strategy: m.strategy}
+	if len(options) == 0 {
+		return opts
+	}
+	return applyCallOptions(opts, options)
+}
+
+// applyCallOptions is separated so options struct escapes to heap only when options are passed.
+func applyCallOptions(opts callOptions, options []CallOption) callOptions {
+	for _, option := range options {
+		option(&opts)
+	}
+	return opts
+}
+
+type task struct {
+	info HandlerInfo
+	run  func(ctx context.Context) error
+}
+
+func runTasks(ctx context.Context, strategy PublishStrategy, tasks []task) error {
+	switch strategy {
+	case ParallelStopOnError:
+		return runStopOnError(ctx, tasks)
+	case ParallelWaitAll:
+		return runWaitAll(ctx, tasks)
+	case Sequential:
+		return runSequential(ctx, tasks)
+	case FireAndForget:
+		ctx = context.WithoutCancel(ctx)
+		for _, t := range tasks {
+			go func() {
+				_ = t.run(ctx)
+			}()
+		}
+		return nil
+	default:
+		panic("mediator: unknown publish strategy")
+	}
+}
+
+// runTasksInScope runs the tasks like runTasks, but with [ParallelStopOnError]
+// it also waits for tasks still running after the failure, so the scope of the call
+// is not disposed under them.
+func runTasksInScope(ctx context.Context, strategy PublishStrategy, tasks []task) error {
+	if strategy != ParallelStopOnError {
+		return runTasks(ctx, strategy, tasks)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(tasks))
+	for i := range tasks {
+		run := tasks[i].run
+		tasks[i].run = func(ctx context.Context) error {
+			defer wg.Done()
+			return run(ctx)
+		}
+	}
+
+	err := runStopOnError(ctx, tasks)
+	wg.Wait()
+	return err
+}
+
+func runStopOnError(ctx context.Context, tasks []task) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	// Channel is never closed, its buffer allows handlers
+	// finished after return to send results without blocking.
+	results := make(chan error, len(tasks))
+	for _, t := range tasks {
+		go func() {
+			if err := t.run(ctx); err != nil {
+				results <- &HandlerError{Handler: t.info, Err: err}
+				return
+			}
+			results <- nil
+		}()
+	}
+
+	for range tasks {
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case err := <-results:
+			if err != nil {
+				cancel(err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func runWaitAll(ctx context.Context, tasks []task) error {
+	errs := make([]error, len(tasks))
+
+	var wg sync.WaitGroup
+	for i, t := range tasks {
+		wg.Go(func() {
+			if err := t.run(ctx); err != nil {
+				errs[i] = &HandlerError{Handler: t.info, Err: err}
+			}
+		})
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func runSequential(ctx context.Context, tasks []task) error {
+	for _, t := range tasks {
+		if err := ctx.Err(); err != nil {
+			return context.Cause(ctx)
+		}
+		if err := t.run(ctx); err != nil {
+			return &HandlerError{Handler: t.info, Err: err}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+)
+
+var errHandler = errors.New("handler failed")
+
+type FailHandler struct {
+	Called atomic.Bool
+}
+
+func (h *FailHandler) Notification(ctx context.Context, e EventX) error {
+	h.Called.Store(true)
+	return errHandler
+}
+
+type CancelAwareHandler struct {
+	Cancelled atomic.Bool
+	Done      chan struct{}
+}
+
+func (h *CancelAwareHandler) Notification(ctx context.Context, e EventX) error {
+	defer close(h.Done)
+	select {
+	case <-ctx.Done():
+		h.Cancelled.Store(true)
+		return ctx.Err()
+	case <-time.After(time.Second):
+		return nil
+	}
+}
+
+func TestPublish_ParallelWaitAll(t *testing.T) {
+	container := octo.New()
+	h1, h2, h3 := &FailHandler{}, &EventHandlerX{}, &FailHandler{}
+	octo.InjectNamedValue(container, "first", h1)
+	octo.InjectValue(container, h2)
+	octo.InjectNamedValue(container, "second", h3)
+	manager := mediator.Inject(container, mediator.WithPublishStrategy(mediator.ParallelWaitAll))
+
+	err := mediator.Publish(manager, context.Background(), EventX{})
+	if !errors.Is(err, errHandler) {
+		t.Fatalf("expected handler error, got %v", err)
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 2 {
+		t.Fatalf("expected 2 joined errors, got %v", err)
+	}
+
+	var handlerErr *mediator.HandlerError
+	if !errors.As(err, &handlerErr) || handlerErr.Handler.Name != "first" {
+		t.Fatalf("expected first handler error, got %v", err)
+	}
+
+	if !h1.Called.Load() || !h2.Called.Load() || !h3.Called.Load() {
+		t.Fatal("expected all handlers called")
+	}
+}
+
+func TestPublish_StopOnErrorCancelsSiblings(t *testing.T) {
+	container := octo.New()
+	slow := &CancelAwareHandler{Done: make(chan struct{})}
+	octo.InjectValue(container, slow)
+	octo.InjectValue(container, &FailHandler{})
+	manager := mediator.Inject(container)
+
+	err := mediator.Publish(manager, context.Background(), EventX{})
+	if !errors.Is(err, errHandler) {
+		t.Fatalf("expected handler error, got %v", err)
+	}
+
+	<-slow.Done
+	if !slow.Cancelled.Load() {
+		t.Fatal("expected sibling handler cancelled")
+	}
+}
+
+func TestPublish_Sequential(t *testing.T) {
+	container := octo.New()
+	h1, h2 := &FailHandler{}, &EventHandlerX{}
+	octo.InjectValue(container, h1)
+	octo.InjectValue(container, h2)
+	manager := mediator.Inject(container)
+
+	err := mediator.Publish(manager, context.Background(), EventX{}, mediator.WithStrategy(mediator.Sequential))
+	if !errors.Is(err, errHandler) {
+		t.Fatalf("expected handler error, got %v", err)
+	}
+	if h2.Called.Load() {
+		t.Fatal("expected second handler not called")
+	}
+}
+
+func TestPublish_FireAndForget(t *testing.T) {
+	container := octo.New()
+	h := &CancelAwareHandler{Done: make(chan struct{})}
+	octo.InjectValue(container, h)
+	manager := mediator.Inject(container)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := mediator.Publish(manager, ctx, EventX{}, mediator.WithStrategy(mediator.FireAndForget))
+	cancel()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	<-h.Done
+	if h.Cancelled.Load() {
+		t.Fatal("expected handler not cancelled with caller context")
+	}
+}
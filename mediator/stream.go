@@ -0,0 +1,98 @@
+package mediator
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"reflect"
+
+	"github.com/oesand/octo"
+)
+
+// StreamRequest [T any] interface declares a "Streams(T)" method
+// Implementations of StreamRequest must define a Streams() method
+// with a single parameter — the type of streamed items.
+//
+// Example:
+//
+//	type ExportUsers struct{}
+//	func (ExportUsers) Streams(User) {}
+//
+// The type system then encodes: ExportUsers → sequence of User
+type StreamRequest[T any] interface {
+	Streams(T)
+}
+
+// StreamHandler is a generic interface for handling stream requests.
+// It takes a request of type TRequest and returns a sequence of T items,
+// a non-nil error in the sequence does not stop it unless the consumer stops.
+type StreamHandler[TRequest StreamRequest[T], T any] interface {
+	// Stream processes the input request and returns a sequence of items.
+	Stream(ctx context.Context, request TRequest) iter.Seq2[T, error]
+}
+
+// StreamHandlerFunc is an adapter to allow the use of ordinary functions as [StreamHandler].
+type StreamHandlerFunc[TRequest StreamRequest[T], T any] func(ctx context.Context, request TRequest) iter.Seq2[T, error]
+
+func (f StreamHandlerFunc[TRequest, T]) Stream(ctx context.Context, request TRequest) iter.Seq2[T, error] {
+	return f(ctx, request)
+}
+
+// HandleStreamFunc registers a function as StreamHandler[TRequest, T] in the container.
+func HandleStreamFunc[TRequest StreamRequest[T], T any](
+	container *octo.Container,
+	handler func(ctx context.Context, request TRequest) iter.Seq2[T, error],
+) {
+	octo.InjectValue[StreamHandler[TRequest, T]](container, StreamHandlerFunc[TRequest, T](handler))
+}
+
+// Stream resolves a StreamHandler for the given request/item types from the container,
+// or from the scope of the call, see [WithScope],
+// and returns the sequence produced by its Stream method.
+//
+// Handler call is wrapped by all [PipelineBehavior] registered in the container,
+// behaviors receive the sequence as response and run when the iteration starts.
+// Iteration stops with the context error once the context is canceled.
+func Stream[TRequest StreamRequest[T], T any](
+	manager *Manager,
+	ctx context.Context,
+	request TRequest,
+) iter.Seq2[T, error] {
+	table := manager.dispatch()
+	handler := octo.Resolve[StreamHandler[TRequest, T]](manager.containerFor(ctx))
+
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		var seq iter.Seq2[T, error]
+		if len(table.behaviors) == 0 {
+			seq = handler.Stream(ctx, request)
+		} else {
+			resp, err := table.runPipeline(ctx, request, func(ctx context.Context) (any, error) {
+				return handler.Stream(ctx, request), nil
+			})
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			var ok bool
+			seq, ok = resp.(iter.Seq2[T, error])
+			if !ok {
+				yield(zero, fmt.Errorf("mediator: pipeline returned %T instead of %s",
+					resp, reflect.TypeFor[iter.Seq2[T, error]]().String()))
+				return
+			}
+		}
+
+		for item, err := range seq {
+			if ctx.Err() != nil {
+				yield(zero, context.Cause(ctx))
+				return
+			}
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+)
+
+type CountRequest struct {
+	mediator.StreamRequest[int]
+	To int
+}
+
+type CountHandler struct{}
+
+func (h *CountHandler) Stream(ctx context.Context, req CountRequest) iter.Seq2[int, error] {
+	return func(yield func(int, error) bool) {
+		for i := 1; i <= req.To; i++ {
+			if !yield(i, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestStream_Items(t *testing.T) {
+	container := octo.New()
+	octo.InjectValue(container, &CountHandler{})
+	manager := mediator.Inject(container)
+
+	var sum int
+	for item, err := range mediator.Stream(manager, context.Background(), CountRequest{To: 4}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sum += item
+	}
+	if sum != 10 {
+		t.Fatalf("expected 10, got %d", sum)
+	}
+}
+
+func TestStream_Cancel(t *testing.T) {
+	container := octo.New()
+	octo.InjectValue(container, &CountHandler{})
+	manager := mediator.Inject(container)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var items []int
+	var lastErr error
+	for item, err := range mediator.Stream(manager, ctx, CountRequest{To: 100}) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		items = append(items, item)
+		if item == 2 {
+			cancel()
+		}
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %v", items)
+	}
+	if !errors.Is(lastErr, context.Canceled) {
+		t.Fatalf("expected canceled error, got %v", lastErr)
+	}
+}
+
+func TestStream_Pipeline(t *testing.T) {
+	denied := errors.New("denied")
+
+	container := octo.New()
+	mediator.HandleStreamFunc(container, func(ctx context.Context, req CountRequest) iter.Seq2[int, error] {
+		t.Fatal("expected handler not called")
+		return nil
+	})
+	octo.InjectValue(container, mediator.PipelineBehaviorFunc(
+		func(ctx context.Context, request any, next func(ctx context.Context) (any, error)) (any, error) {
+			return nil, denied
+		}))
+	manager := mediator.Inject(container)
+
+	for _, err := range mediator.Stream(manager, context.Background(), CountRequest{To: 1}) {
+		if !errors.Is(err, denied) {
+			t.Fatalf("expected denied error, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package mediator
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// Subscribe registers a function handler for events of type TEvent
+// without injecting it into the container.
+// Returns a function which removes the subscription, it is safe to call it multiple times.
+//
+// If TEvent is an interface, handler receives every event implementing it.
+func Subscribe[TEvent any](manager *Manager, handler func(ctx context.Context, event TEvent) error) (unsubscribe func()) {
+	manager.ensureInit()
+
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+
+	id := manager.nextID()
+	manager.subscriptions = append(manager.subscriptions, registration{
+		eventType: reflect.TypeFor[TEvent](),
+		handler: eventHandler{
+			id: id,
+			info: HandlerInfo{
+				Type: reflect.TypeOf(handler),
+				ID:   reflect.TypeOf(handler).String() + "#sub" + strconv.Itoa(id),
+			},
+			handle: func(ctx context.Context, event any) error {
+				return handler(ctx, event.(TEvent))
+			},
+		},
+	})
+	manager.rebuild()
+
+	return func() {
+		manager.mu.Lock()
+		defer manager.mu.Unlock()
+
+		manager.subscriptions = slices.DeleteFunc(manager.subscriptions, func(reg registration) bool {
+			return reg.handler.id == id
+		})
+		manager.rebuild()
+	}
+}
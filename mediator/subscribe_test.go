@@ -0,0 +1,94 @@
+package mediator_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+)
+
+func TestSubscribe_Unsubscribe(t *testing.T) {
+	container := octo.New()
+	manager := mediator.Inject(container)
+
+	var received atomic.Int32
+	unsubscribe := mediator.Subscribe(manager, func(ctx context.Context, event EventX) error {
+		if event.Name != "sub" {
+			t.Errorf("unexpected event: %v", event)
+		}
+		received.Add(1)
+		return nil
+	})
+
+	if err := mediator.Publish(manager, context.Background(), EventX{Name: "sub"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unsubscribe()
+	unsubscribe()
+	if err := mediator.Publish(manager, context.Background(), EventX{Name: "sub"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := received.Load(); n != 1 {
+		t.Fatalf("expected 1 event, got %d", n)
+	}
+}
+
+func TestSubscribe_Interface(t *testing.T) {
+	manager := mediator.Inject(octo.New())
+
+	var received atomic.Int32
+	mediator.Subscribe(manager, func(ctx context.Context, event DomainEvent) error {
+		received.Add(1)
+		return nil
+	})
+
+	if err := mediator.Publish(manager, context.Background(), UserCreated{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mediator.Publish(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := received.Load(); n != 1 {
+		t.Fatalf("expected 1 event, got %d", n)
+	}
+}
+
+func TestRefresh_DiscoversLateHandlers(t *testing.T) {
+	container := octo.New()
+	manager := mediator.Inject(container)
+
+	var subscribed atomic.Bool
+	mediator.Subscribe(manager, func(ctx context.Context, event EventX) error {
+		subscribed.Store(true)
+		return nil
+	})
+	if err := mediator.Publish(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	late := &EventHandlerX{}
+	octo.InjectValue(container, late)
+
+	if err := mediator.Publish(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if late.Called.Load() {
+		t.Fatal("expected late handler not discovered before refresh")
+	}
+
+	manager.Refresh()
+	subscribed.Store(false)
+	if err := mediator.Publish(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !late.Called.Load() {
+		t.Fatal("expected late handler called after refresh")
+	}
+	if !subscribed.Load() {
+		t.Fatal("expected subscription kept after refresh")
+	}
+}
@@ -0,0 +1,45 @@
+package mediator
+
+import (
+	"context"
+	"reflect"
+)
+
+// Transport forwards requests and events to a remote service,
+// it allows handlers to be moved to another process while callers keep using [Send] and [Publish].
+type Transport interface {
+	// Send sends the request to the remote handler and decodes its response into the response pointer.
+	Send(ctx context.Context, request any, response any) error
+
+	// Publish publishes the event to the remote service.
+	Publish(ctx context.Context, event any) error
+
+	// Accepts reports whether events of the type are forwarded by [Publish].
+	Accepts(eventType reflect.Type) bool
+}
+
+// WithTransport sets the transport used by [Send] for requests without local handler
+// and by [Publish] to forward events accepted by the transport along with local handlers.
+func WithTransport(transport Transport) Option {
+	return func(m *Manager) {
+		m.transport = transport
+	}
+}
+
+type remoteRequestHandler[TRequest Request[TResponse], TResponse any] struct {
+	transport Transport
+}
+
+func (h remoteRequestHandler[TRequest, TResponse]) Request(ctx context.Context, request TRequest) (TResponse, error) {
+	var response TResponse
+	err := h.transport.Send(ctx, request, &response)
+	return response, err
+}
+
+// remoteEventHandler returns the handler forwarding events to the transport.
+func remoteEventHandler(transport Transport) eventHandler {
+	return eventHandler{
+		info:   HandlerInfo{Type: reflect.TypeOf(transport), Name: "remote", ID: "remote"},
+		handle: transport.Publish,
+	}
+}
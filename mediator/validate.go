@@ -0,0 +1,110 @@
+package mediator
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/oesand/octo"
+)
+
+var seqType = reflect.TypeFor[iter.Seq2[any, error]]()
+
+// Validate checks handler registrations in the manager container and returns all found issues joined.
+// It is intended to be called once at startup, reported issues are:
+//   - Request and stream types handled by multiple handlers.
+//   - Expected request types passed as requests which have no handler.
+//   - Notification methods with a signature [Publish] cannot call,
+//     such handlers are never called.
+//   - MassEventHandler event types which are not valid event types.
+//   - MassEventHandler registered with [octo.InjectScoped], such handlers are never called.
+//
+// Example:
+//
+//	err := mediator.Validate(manager, reflect.TypeFor[GetUser](), reflect.TypeFor[ExportUsers]())
+func Validate(manager *Manager, requests ...reflect.Type) error {
+	manager.ensureInit()
+
+	var issues []error
+	handlers := make(map[reflect.Type][]string)
+
+	massHandlerType := reflect.TypeFor[MassEventHandler]()
+	for decl := range octo.ResolveInjections(manager.container) {
+		typ := decl.Type()
+		info := HandlerInfo{Type: typ, Name: decl.Name()}
+
+		if requestType, ok := requestHandlerType(typ); ok {
+			handlers[requestType] = append(handlers[requestType], info.String())
+		}
+
+		if _, _, has := methodParams(typ, "Notification"); has {
+			if _, ok := notificationEventType(typ); !ok {
+				issues = append(issues, fmt.Errorf("mediator: handler %s has Notification method with unsupported signature, "+
+					"expected Notification(context.Context, TEvent) error", info))
+			}
+		}
+
+		if typ.Implements(massHandlerType) && octo.IsScoped(decl) {
+			issues = append(issues, fmt.Errorf("mediator: mass handler %s is scoped, such handlers are never called", info))
+		} else if typ.Implements(massHandlerType) {
+			for _, eventType := range decl.Value().(MassEventHandler).EventTypes() {
+				if !validEventType(eventType) {
+					issues = append(issues, fmt.Errorf("mediator: handler %s declares invalid event type %v", info, eventType))
+				}
+			}
+		}
+	}
+
+	for _, requestType := range requests {
+		if len(handlers[requestType]) == 0 {
+			issues = append(issues, fmt.Errorf("mediator: request %s has no handler", requestType.String()))
+		}
+	}
+
+	var duplicated []string
+	for requestType, infos := range handlers {
+		if len(infos) > 1 {
+			duplicated = append(duplicated, fmt.Sprintf("mediator: request %s has %d handlers: %s",
+				requestType.String(), len(infos), strings.Join(infos, ", ")))
+		}
+	}
+	slices.Sort(duplicated)
+	for _, issue := range duplicated {
+		issues = append(issues, errors.New(issue))
+	}
+
+	return errors.Join(issues...)
+}
+
+// requestHandlerType returns the request type of the handler if it has method
+// Request(context.Context, TRequest) (TResponse, error) or
+// Stream(context.Context, TRequest) iter.Seq2[T, error].
+func requestHandlerType(typ reflect.Type) (reflect.Type, bool) {
+	if in, out, ok := methodParams(typ, "Request"); ok && len(in) == 2 && in[0].AssignableTo(ctxType) &&
+		len(out) == 2 && out[1].AssignableTo(errorType) {
+		return in[1], true
+	}
+
+	if in, out, ok := methodParams(typ, "Stream"); ok && len(in) == 2 && in[0].AssignableTo(ctxType) &&
+		len(out) == 1 && out[0].Kind() == seqType.Kind() && out[0].NumIn() == seqType.NumIn() {
+		return in[1], true
+	}
+
+	return nil, false
+}
+
+func validEventType(typ reflect.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	switch typ.Kind() {
+	case reflect.Invalid, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return false
+	default:
+		return true
+	}
+}
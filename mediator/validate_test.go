@@ -0,0 +1,92 @@
+package mediator_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/oesand/octo"
+	"github.com/oesand/octo/mediator"
+)
+
+type WrongSignatureHandler struct{}
+
+func (h *WrongSignatureHandler) Notification(e EventX) error {
+	return nil
+}
+
+type InvalidTypesHandler struct{}
+
+func (h *InvalidTypesHandler) EventTypes() []reflect.Type {
+	return []reflect.Type{reflect.TypeFor[EventX](), nil, reflect.TypeFor[func()]()}
+}
+
+func (h *InvalidTypesHandler) Handle(ctx context.Context, event any) error {
+	return nil
+}
+
+func TestValidate_Valid(t *testing.T) {
+	container := octo.New()
+	octo.InjectValue(container, &TestRequestHandler{})
+	octo.InjectValue(container, &EventHandlerX{})
+	octo.InjectValue(container, &CountHandler{})
+	manager := mediator.Inject(container)
+
+	err := mediator.Validate(manager, reflect.TypeFor[TestRequest](), reflect.TypeFor[CountRequest]())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidate_Issues(t *testing.T) {
+	container := octo.New()
+	octo.InjectValue(container, &TestRequestHandler{})
+	mediator.HandleFunc(container, func(ctx context.Context, req TestRequest) (TestResponse, error) {
+		return TestResponse{}, nil
+	})
+	octo.InjectValue(container, &WrongSignatureHandler{})
+	octo.InjectValue(container, &InvalidTypesHandler{})
+	manager := mediator.Inject(container)
+
+	err := mediator.Validate(manager, reflect.TypeFor[TestRequest](), reflect.TypeFor[QuoteRequest]())
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	issues := strings.Split(err.Error(), "\n")
+	want := []string{
+		"WrongSignatureHandler has Notification method with unsupported signature",
+		"InvalidTypesHandler declares invalid event type <nil>",
+		"InvalidTypesHandler declares invalid event type func()",
+		"request mediator_test.QuoteRequest has no handler",
+		"request mediator_test.TestRequest has 2 handlers",
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d issues, got:\n%v", len(want), err)
+	}
+	for i, issue := range want {
+		if !strings.Contains(issues[i], issue) {
+			t.Fatalf("expected issue %q, got %q", issue, issues[i])
+		}
+	}
+}
+
+func TestPublish_InterfaceRegisteredHandler(t *testing.T) {
+	container := octo.New()
+	var called atomic.Bool
+	octo.InjectValue[mediator.EventHandler[EventX]](container, mediator.EventHandlerFunc[EventX](
+		func(ctx context.Context, event EventX) error {
+			called.Store(true)
+			return nil
+		}))
+	manager := mediator.Inject(container)
+
+	if err := mediator.Publish(manager, context.Background(), EventX{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called.Load() {
+		t.Fatal("expected handler registered by interface called")
+	}
+}
@@ -0,0 +1,104 @@
+package octo
+
+import (
+	"strings"
+)
+
+// Module is a reusable group of registrations.
+//
+// A module is installed into a container at most once and only after
+// all of its required modules, so shared dependencies can be listed by
+// every module that needs them without duplicating declarations.
+//
+// Modules are identified by pointer, declare them as package level variables:
+//
+//	var StorageModule = &octo.Module{
+//		Name:    "storage",
+//		Install: IncludeStorage,
+//	}
+//
+//	var ServiceModule = &octo.Module{
+//		Name:     "service",
+//		Install:  IncludeService,
+//		Requires: []*octo.Module{StorageModule},
+//	}
+type Module struct {
+	// Name is a human-readable name of the module used in diagnostics.
+	Name string
+
+	// Install registers the module declarations into the container.
+	// It receives a view of the container attributing registrations made through it to the module.
+	Install func(*Container)
+
+	// Requires lists modules that must be installed before this one.
+	Requires []*Module
+}
+
+// Install installs modules and all of their required modules into the container.
+// Every module is installed exactly once, already installed modules are skipped.
+// Panics if modules have a circular dependency.
+//
+// Panics if called inside a module Install function, use Module.Requires instead.
+func Install(container *Container, modules ...*Module) {
+	if container != nil && container.target != nil {
+		panic("octo: Install must not be called inside a module Install function, use Module.Requires instead")
+	}
+
+	container = containerOrDefault(container)
+	container.modulesMu.Lock()
+	defer container.modulesMu.Unlock()
+
+	var visiting []*Module
+	for _, module := range modules {
+		installModule(container, module, &visiting)
+	}
+}
+
+func installModule(container *Container, module *Module, visiting *[]*Module) {
+	if module == nil {
+		panic("octo: module must not be nil")
+	}
+
+	if container.modules.Has(module) {
+		return
+	}
+
+	for i, parent := range *visiting {
+		if parent == module {
+			names := make([]string, 0, len(*visiting)-i+1)
+			for _, m := range (*visiting)[i:] {
+				names = append(names, m.Name)
+			}
+			names = append(names, module.Name)
+			panic("octo: circular module dependency " + strings.Join(names, " -> "))
+		}
+	}
+
+	*visiting = append(*visiting, module)
+	for _, required := range module.Requires {
+		installModule(container, required, visiting)
+	}
+	*visiting = (*visiting)[:len(*visiting)-1]
+
+	if module.Install != nil {
+		module.Install(moduleView(container, module))
+	}
+
+	container.modules.Add(module)
+}
+
+// moduleView returns the view of the container passed to the module Install function.
+// View registers into the container, attributing declarations to the module
+// without affecting registrations made into the container directly.
+func moduleView(container *Container, module *Module) *Container {
+	return &Container{target: container, installing: module}
+}
+
+// Installed reports whether the module has been installed into the container.
+func Installed(container *Container, module *Module) bool {
+	container = containerOrDefault(container)
+	container.modulesMu.Lock()
+	defer container.modulesMu.Unlock()
+
+	return container.modules.Has(module)
+}
@@ -0,0 +1,132 @@
+package octo_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oesand/octo"
+)
+
+func TestInstall_OnceInDependencyOrder(t *testing.T) {
+	var order []string
+
+	base := &octo.Module{
+		Name: "base",
+		Install: func(c *octo.Container) {
+			order = append(order, "base")
+			octo.InjectValue(c, &OtherService{})
+		},
+	}
+	service := &octo.Module{
+		Name: "service",
+		Install: func(c *octo.Container) {
+			order = append(order, "service")
+			octo.InjectValue(c, &MyService{name: "service"})
+		},
+		Requires: []*octo.Module{base},
+	}
+
+	c := octo.New()
+	octo.Install(c, service, base)
+	octo.Install(c, service)
+
+	if got := strings.Join(order, ","); got != "base,service" {
+		t.Fatalf("unexpected install order: %s", got)
+	}
+
+	if n := len(octo.ResolveAll[*MyService](c)); n != 1 {
+		t.Fatalf("expected 1 MyService, got %d", n)
+	}
+
+	if !octo.Installed(c, base) || !octo.Installed(c, service) {
+		t.Fatal("expected modules installed")
+	}
+}
+
+func TestInstall_RecordsModule(t *testing.T) {
+	module := &octo.Module{
+		Name: "module",
+		Install: func(c *octo.Container) {
+			octo.InjectValue(c, &MyService{})
+		},
+	}
+
+	c := octo.New()
+	octo.InjectValue(c, &OtherService{})
+	octo.Install(c, module)
+
+	for decl := range octo.ResolveInjections(c) {
+		switch {
+		case octo.OfType[*MyService](decl):
+			if decl.Module() != module {
+				t.Fatalf("expected MyService from module, got %v", decl.Module())
+			}
+		case octo.OfType[*OtherService](decl):
+			if decl.Module() != nil {
+				t.Fatalf("expected OtherService without module, got %v", decl.Module())
+			}
+		}
+	}
+}
+
+func TestInstall_PanicsOnCycle(t *testing.T) {
+	a := &octo.Module{Name: "a"}
+	b := &octo.Module{Name: "b", Requires: []*octo.Module{a}}
+	a.Requires = []*octo.Module{b}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("expected panic for circular modules")
+		}
+		if msg, _ := r.(string); msg != "octo: circular module dependency a -> b -> a" {
+			t.Fatalf("unexpected panic message: %v", r)
+		}
+	}()
+
+	octo.Install(octo.New(), a)
+}
+
+func TestInstall_ConcurrentRegistrations(t *testing.T) {
+	c := octo.New()
+	module := &octo.Module{
+		Name: "module",
+		Install: func(mc *octo.Container) {
+			done := make(chan struct{})
+			go func() {
+				octo.InjectValue(c, &OtherService{})
+				close(done)
+			}()
+			<-done
+			octo.InjectValue(mc, &MyService{})
+		},
+	}
+	octo.Install(c, module)
+
+	for decl := range octo.ResolveInjections(c) {
+		if octo.OfType[*OtherService](decl) && decl.Module() != nil {
+			t.Fatalf("expected concurrent registration without module, got %v", decl.Module())
+		}
+		if octo.OfType[*MyService](decl) && decl.Module() != module {
+			t.Fatalf("expected MyService from module, got %v", decl.Module())
+		}
+	}
+}
+
+func TestInstall_PanicsOnNested(t *testing.T) {
+	inner := &octo.Module{Name: "inner"}
+	outer := &octo.Module{
+		Name: "outer",
+		Install: func(c *octo.Container) {
+			octo.Install(c, inner)
+		},
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic for nested install")
+		}
+	}()
+
+	octo.Install(octo.New(), outer)
+}
@@ -8,17 +8,39 @@ import (
 	"github.com/oesand/octo/internal"
 )
 
+var containerType = reflect.TypeFor[*Container]()
+
 func resolve[T any](container *Container, name string) Declaration {
+	return resolveType(container, reflect.TypeFor[T](), name)
+}
+
+// resolveType returns the declaration from the container or its parents,
+// the container lock must be held by the caller.
+func resolveType(container *Container, typ reflect.Type, name string) Declaration {
+	decl := resolveOwnType(container, typ, name)
+	if decl == nil && container.parent != nil {
+		container.parent.mu.RLock()
+		decl = resolveType(container.parent, typ, name)
+		container.parent.mu.RUnlock()
+	}
+	return decl
+}
+
+func resolveOwnType(container *Container, typ reflect.Type, name string) Declaration {
 	if container.injects == nil {
 		return nil
 	}
 
-	var typeKey internal.Type[T]
+	if len(container.bindings) > 0 {
+		if decl, ok := container.bindings[bindingKey{typ: typ, name: name}]; ok {
+			return decl
+		}
+	}
 
 	if name == "" {
 		container.resolveCacheMu.RLock()
 		if len(container.resolveCache) > 0 {
-			if decl, ok := container.resolveCache[typeKey]; ok {
+			if decl, ok := container.resolveCache[typ]; ok {
 				container.resolveCacheMu.RUnlock()
 				return decl
 			}
@@ -27,8 +49,8 @@ func resolve[T any](container *Container, name string) Declaration {
 	}
 
 	var decl Declaration
-	if typeKey.Real() {
-		if group, ok := container.injects[typeKey]; ok && len(group) > 0 {
+	if typ.Kind() != reflect.Interface {
+		if group, ok := container.injects[typ]; ok && len(group) > 0 {
 			for _, inject := range group {
 				if name != "" && inject.Name() != name {
 					continue
@@ -39,9 +61,9 @@ func resolve[T any](container *Container, name string) Declaration {
 			}
 		}
 	} else {
-		resolveType := typeKey.Type()
-		for groupType, group := range container.injects {
-			if len(group) == 0 || !groupType.Type().AssignableTo(resolveType) {
+		for _, groupType := range container.order {
+			group := container.injects[groupType]
+			if len(group) == 0 || !groupType.AssignableTo(typ) {
 				continue
 			}
 
@@ -63,10 +85,10 @@ func resolve[T any](container *Container, name string) Declaration {
 	if name == "" && decl != nil {
 		container.resolveCacheMu.Lock()
 		if container.resolveCache == nil {
-			container.resolveCache = make(map[internal.ShadowType]Declaration)
+			container.resolveCache = make(map[reflect.Type]Declaration)
 		}
 
-		container.resolveCache[typeKey] = decl
+		container.resolveCache[typ] = decl
 		container.resolveCacheMu.Unlock()
 	}
 
@@ -83,11 +105,14 @@ func resolveValue[T any](container *Container, name string, required bool) (resu
 	}
 
 	container.mu.RLock()
-	defer container.mu.RUnlock()
-
 	decl := resolve[T](container, name)
+	container.mu.RUnlock()
 
 	if decl != nil {
+		decl = scopeDeclaration(container, decl)
+		if !required && IsScoped(decl) {
+			return
+		}
 		if val := decl.Value(); val != nil {
 			result = val.(T)
 		}
@@ -111,29 +136,36 @@ func ResolveNamed[T any](container *Container, name string) T {
 }
 
 // TryResolve attempts to return the first registered instance of type T.
-// Returns zero value if not found.
+// Returns zero value if not found or T is scoped and the container is not a scope.
 func TryResolve[T any](container *Container) T {
 	return TryResolveNamed[T](container, "")
 }
 
 // TryResolveNamed returns the instance of type T with the specified name.
-// Returns zero value if not found.
+// Returns zero value if not found or T is scoped and the container is not a scope.
 func TryResolveNamed[T any](container *Container, name string) T {
 	return resolveValue[T](container, name, false)
 }
 
+func snapshotInjections(container *Container) []Declaration {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+
+	var injects []Declaration
+	for _, groupType := range container.order {
+		injects = append(injects, container.injects[groupType]...)
+	}
+	return injects
+}
+
 // ResolveInjections returns an iterator over all registered injects in the container.
+// Iterates over a snapshot taken at the start, so the container can be modified while iterating.
 func ResolveInjections(container *Container) iter.Seq[Declaration] {
 	container = containerOrDefault(container)
 	return func(yield func(Declaration) bool) {
-		container.mu.RLock()
-		defer container.mu.RUnlock()
-
-		for _, group := range container.injects {
-			for _, inject := range group {
-				if !yield(inject) {
-					return
-				}
+		for _, inject := range snapshotInjections(container) {
+			if !yield(inject) {
+				return
 			}
 		}
 	}
@@ -141,47 +173,60 @@ func ResolveInjections(container *Container) iter.Seq[Declaration] {
 
 // ResolveAll returns slice of registered injects in the container
 // if the service's type is assignable to T (implements interface or same type).
+// Scope returns its own injects followed by the ones of its parents,
+// injects registered with [InjectScoped] are skipped outside of a scope.
 func ResolveAll[T any](container *Container) []T {
 	container = containerOrDefault(container)
-	container.mu.RLock()
-	defer container.mu.RUnlock()
-
-	var result []T
-	if container.injects == nil {
-		return result
-	}
+	typ := reflect.TypeFor[T]()
 
-	var typeKey internal.Type[T]
-	if typeKey.Real() {
-		if group, ok := container.injects[typeKey]; ok {
-			for _, inject := range group {
-				result = append(result, inject.Value().(T))
-			}
-		}
-	} else {
-		resolveType := typeKey.Type()
-		for groupType, group := range container.injects {
-			if groupType.Type().AssignableTo(resolveType) {
-				for _, inject := range group {
-					result = append(result, inject.Value().(T))
+	var injects []Declaration
+	for owner := container; owner != nil; owner = owner.parent {
+		owner.mu.RLock()
+		if typ.Kind() != reflect.Interface {
+			injects = append(injects, owner.injects[typ]...)
+		} else {
+			for _, groupType := range owner.order {
+				if groupType.AssignableTo(typ) {
+					injects = append(injects, owner.injects[groupType]...)
 				}
 			}
 		}
+		owner.mu.RUnlock()
+	}
+
+	var result []T
+	for _, inject := range injects {
+		inject = scopeDeclaration(container, inject)
+		if IsScoped(inject) {
+			continue
+		}
+		result = append(result, inject.Value().(T))
 	}
 	return result
 }
 
 // CleanInjections removes all inject declarations that match the selector function.
-// Do not use octo.* functions inside selector, this may cause deadlocks
+// Selector is called without holding the container lock, so it is safe to use octo.* functions inside.
+// Declarations registered while selecting are kept.
 func CleanInjections(container *Container, selector func(decl Declaration) bool) {
 	container = containerOrDefault(container)
+
+	var selected internal.Set[Declaration]
+	for _, inject := range snapshotInjections(container) {
+		if selector(inject) {
+			selected.Add(inject)
+		}
+	}
+
 	container.mu.Lock()
 	defer container.mu.Unlock()
 
-	for groupType, group := range container.injects {
+	order := container.order[:0]
+	for _, groupType := range container.order {
+		group := container.injects[groupType]
 		injects := make([]Declaration, 0, len(group))
 		for _, inject := range group {
-			if !selector(inject) {
+			if !selected.Has(inject) {
 				injects = append(injects, inject)
 			}
 		}
@@ -190,8 +235,44 @@ func CleanInjections(container *Container, selector func(decl Declaration) bool)
 			delete(container.injects, groupType)
 		} else {
 			container.injects[groupType] = injects
+			order = append(order, groupType)
+		}
+	}
+	container.order = order
+
+	for key, decl := range container.bindings {
+		if selected.Has(decl) {
+			delete(container.bindings, key)
 		}
 	}
 
 	container.resolveCache = nil
 }
+
+// ResolveType returns the first registered instance assignable to typ with the specified name.
+// Returns an error if not found or the provider failed.
+//
+// Not recommended, use if generics are not applicable in your case
+func ResolveType(container *Container, typ reflect.Type, name string) (any, error) {
+	container = containerOrDefault(container)
+	if typ == containerType {
+		return container, nil
+	}
+
+	container.mu.RLock()
+	decl := resolveType(container, typ, name)
+	container.mu.RUnlock()
+
+	if decl == nil {
+		return nil, fmt.Errorf("octo: fail to resolve type %s", typ.String())
+	}
+	decl = scopeDeclaration(container, decl)
+	if IsScoped(decl) {
+		return nil, fmt.Errorf("octo: scoped type %s resolved outside of scope", typ.String())
+	}
+
+	if inject, ok := decl.(*reflectInjection); ok {
+		return inject.resolve()
+	}
+	return decl.Value(), nil
+}
@@ -0,0 +1,155 @@
+package octo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// NewScope creates a child container of the parent.
+// Scope resolves its own injections first and falls back to the parent,
+// injections registered with [InjectScoped] are instantiated once per scope.
+//
+// Values specific to the scope, like request metadata or a transaction,
+// can be injected into the scope without affecting the parent.
+// Call [DisposeScope] when the scope is no longer used.
+func NewScope(parent *Container) *Container {
+	return &Container{parent: containerOrDefault(parent)}
+}
+
+// InjectScoped registers a provider creating a new instance of T in every scope.
+// Provider receives the scope, so it can resolve other scoped and scope specific values.
+// Resolving T outside a scope panics, [TryResolve] returns zero value.
+func InjectScoped[T any](container *Container, provider Provider[T]) {
+	InjectScopedNamed(container, "", provider)
+}
+
+// InjectScopedNamed registers a named provider creating a new instance of T in every scope.
+func InjectScopedNamed[T any](container *Container, name string, provider Provider[T]) {
+	ensureCanInjectType[T]()
+
+	container, module := registrationTarget(container)
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	addInjection(container, reflect.TypeFor[T](), &scopedInjection[T]{
+		module:   module,
+		name:     name,
+		provider: provider,
+	})
+}
+
+// DisposeScope closes instances created by the scope which implement [io.Closer]
+// in reverse order of creation and returns their errors joined.
+// Scoped injections cannot be resolved from the disposed scope.
+func DisposeScope(scope *Container) error {
+	scope.scopeMu.Lock()
+	if scope.disposed {
+		scope.scopeMu.Unlock()
+		return nil
+	}
+	scope.disposed = true
+	instances := scope.scopedOrder
+	scope.scopedOrder = nil
+	scope.scopedValues = nil
+	scope.scopeMu.Unlock()
+
+	var errs []error
+	for i := len(instances) - 1; i >= 0; i-- {
+		instance := instances[i]
+		if !instance.instantiated() {
+			continue
+		}
+		if closer, ok := instance.Value().(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ResolveDeclaration returns the value of the declaration as resolved from the container,
+// so scoped declarations of the parent return the instance of the scope.
+func ResolveDeclaration(container *Container, decl Declaration) any {
+	return scopeDeclaration(containerOrDefault(container), decl).Value()
+}
+
+// IsScoped reports whether the declaration is registered with [InjectScoped],
+// so its value can be resolved only from a scope.
+func IsScoped(decl Declaration) bool {
+	_, ok := decl.(scopedDeclaration)
+	return ok
+}
+
+type scopedDeclaration interface {
+	Declaration
+	inScope(scope *Container) instantiableDeclaration
+}
+
+type instantiableDeclaration interface {
+	Declaration
+	instantiable
+}
+
+// scopeDeclaration returns the instance of the scoped declaration for the container,
+// other declarations are returned as is.
+func scopeDeclaration(container *Container, decl Declaration) Declaration {
+	scoped, ok := decl.(scopedDeclaration)
+	if !ok || container.parent == nil {
+		return decl
+	}
+
+	container.scopeMu.Lock()
+	defer container.scopeMu.Unlock()
+
+	if container.disposed {
+		panic(fmt.Sprintf("octo: resolve scoped type %s from disposed scope", decl.Type().String()))
+	}
+
+	if instance, ok := container.scopedValues[decl]; ok {
+		return instance
+	}
+	if container.scopedValues == nil {
+		container.scopedValues = make(map[Declaration]instantiableDeclaration)
+	}
+
+	instance := scoped.inScope(container)
+	container.scopedValues[decl] = instance
+	container.scopedOrder = append(container.scopedOrder, instance)
+	return instance
+}
+
+type scopedInjection[T any] struct {
+	module   *Module
+	name     string
+	provider Provider[T]
+}
+
+func (c *scopedInjection[T]) Type() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (c *scopedInjection[T]) Name() string {
+	return c.name
+}
+
+func (c *scopedInjection[T]) Module() *Module {
+	return c.module
+}
+
+func (c *scopedInjection[T]) Value() any {
+	panic(fmt.Sprintf("octo: scoped type %s resolved outside of scope", c.Type().String()))
+}
+
+func (c *scopedInjection[T]) instantiated() bool {
+	return false
+}
+
+func (c *scopedInjection[T]) inScope(scope *Container) instantiableDeclaration {
+	return &lazyInjection[T]{
+		container: scope,
+		module:    c.module,
+		name:      c.name,
+		provider:  c.provider,
+	}
+}
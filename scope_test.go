@@ -0,0 +1,151 @@
+package octo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oesand/octo"
+)
+
+type Session struct {
+	User   string
+	Closed bool
+	order  *[]string
+}
+
+func (s *Session) Close() error {
+	s.Closed = true
+	*s.order = append(*s.order, s.User)
+	return nil
+}
+
+type Repository struct {
+	Session *Session
+}
+
+func TestScope_ScopedInstances(t *testing.T) {
+	var closed []string
+	c := octo.New()
+	octo.InjectValue(c, &OtherService{})
+	octo.InjectScoped(c, func(c *octo.Container) *Session {
+		return &Session{User: octo.ResolveNamed[string](c, "user"), order: &closed}
+	})
+	octo.InjectScoped(c, func(c *octo.Container) *Repository {
+		return &Repository{Session: octo.Resolve[*Session](c)}
+	})
+
+	first := octo.NewScope(c)
+	octo.InjectNamedValue(first, "user", "alice")
+	second := octo.NewScope(c)
+	octo.InjectNamedValue(second, "user", "bob")
+
+	repo := octo.Resolve[*Repository](first)
+	if repo.Session != octo.Resolve[*Session](first) {
+		t.Fatal("expected single instance per scope")
+	}
+	if repo.Session.User != "alice" || octo.Resolve[*Repository](second).Session.User != "bob" {
+		t.Fatal("expected instances resolved with scope values")
+	}
+	if octo.Resolve[*OtherService](first) != octo.Resolve[*OtherService](c) {
+		t.Fatal("expected parent singleton shared with scope")
+	}
+
+	if err := octo.DisposeScope(first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !repo.Session.Closed || len(closed) != 1 || closed[0] != "alice" {
+		t.Fatalf("expected scope instances closed, got %v", closed)
+	}
+}
+
+func TestScope_ResolveOutsideScope(t *testing.T) {
+	c := octo.New()
+	octo.InjectScoped(c, func(c *octo.Container) *Session {
+		return &Session{}
+	})
+	disposed := octo.NewScope(c)
+	_ = octo.DisposeScope(disposed)
+
+	for name, container := range map[string]*octo.Container{"root": c, "disposed": disposed} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Fatal("expected panic")
+				}
+			}()
+
+			octo.Resolve[*Session](container)
+		})
+	}
+}
+
+func TestScope_ResolveType(t *testing.T) {
+	c := octo.New()
+	octo.InjectScoped(c, func(c *octo.Container) *Session {
+		return &Session{User: "scoped"}
+	})
+	scope := octo.NewScope(c)
+
+	value, err := octo.ResolveType(scope, reflect.TypeFor[*Session](), "")
+	if err != nil || value.(*Session) != octo.Resolve[*Session](scope) {
+		t.Fatalf("expected scope instance, got %v %v", value, err)
+	}
+
+	var decl octo.Declaration
+	for d := range octo.ResolveInjections(c) {
+		decl = d
+	}
+	if octo.ResolveDeclaration(scope, decl) != value {
+		t.Fatal("expected declaration resolved to scope instance")
+	}
+	if !octo.OfType[*Session](decl) {
+		t.Fatal("expected scoped declaration of type")
+	}
+}
+
+func TestScope_OverridesParent(t *testing.T) {
+	c := octo.New()
+	octo.InjectValue(c, &MyService{name: "root"})
+	scope := octo.NewScope(c)
+	octo.InjectValue(scope, &MyService{name: "scope"})
+
+	if s := octo.Resolve[*MyService](scope); s.Name() != "scope" {
+		t.Fatalf("expected scope value, got %s", s.Name())
+	}
+	if s := octo.Resolve[*MyService](c); s.Name() != "root" {
+		t.Fatalf("expected parent unchanged, got %s", s.Name())
+	}
+}
+
+func TestScope_ResolveAll(t *testing.T) {
+	c := octo.New()
+	octo.InjectValue(c, &Session{User: "root"})
+	octo.InjectScoped(c, func(c *octo.Container) *Session {
+		return &Session{User: "scoped"}
+	})
+	scope := octo.NewScope(c)
+	octo.InjectValue(scope, &Session{User: "scope"})
+
+	if sessions := octo.ResolveAll[*Session](c); len(sessions) != 1 || sessions[0].User != "root" {
+		t.Fatalf("expected scoped injection skipped outside of scope, got %v", sessions)
+	}
+
+	sessions := octo.ResolveAll[*Session](scope)
+	if len(sessions) != 3 || sessions[0].User != "scope" || sessions[1].User != "root" || sessions[2].User != "scoped" {
+		t.Fatalf("expected scope and parent injections, got %v", sessions)
+	}
+}
+
+func TestScope_TryResolveOutsideScope(t *testing.T) {
+	c := octo.New()
+	octo.InjectScoped(c, func(c *octo.Container) *Session {
+		return &Session{}
+	})
+
+	if s := octo.TryResolve[*Session](c); s != nil {
+		t.Fatalf("expected zero value outside of scope, got %v", s)
+	}
+	if _, err := octo.ResolveType(c, reflect.TypeFor[*Session](), ""); err == nil {
+		t.Fatal("expected error outside of scope")
+	}
+}
@@ -17,6 +17,10 @@ type Declaration interface {
 
 	// Value returns the concrete instance of the injection, if available.
 	Value() any
+
+	// Module returns the module that contributed the injection,
+	// or nil if it was registered outside of [Install].
+	Module() *Module
 }
 
 // OfType checks if a ServiceDeclaration is compatible with type T.
@@ -30,7 +34,14 @@ func OfType[T any](decl Declaration) bool {
 		return true
 	}
 
+	if _, ok := decl.(*scopedInjection[T]); ok {
+		return true
+	}
+
 	expectType := reflect.TypeFor[T]()
+	if decl.Type() == expectType {
+		return true
+	}
 	return expectType.Kind() == reflect.Interface &&
 		decl.Type().AssignableTo(expectType)
 }